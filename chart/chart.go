@@ -0,0 +1,187 @@
+// Package chart renders vote history line charts as SVG or PNG without external services
+package chart
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Point - Value of a series at a time
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Series - Named line drawn in a chart
+type Series struct {
+	Name   string
+	Color  color.RGBA
+	Points []Point
+}
+
+// Chart - Line chart of several series sharing the time and value axes
+type Chart struct {
+	Title  string
+	Width  int
+	Height int
+	Series []Series
+}
+
+// Palette - Default series colors, cycled when a chart has more series
+var Palette = []color.RGBA{
+	{R: 0x1f, G: 0x77, B: 0xb4, A: 0xff},
+	{R: 0xd6, G: 0x27, B: 0x28, A: 0xff},
+	{R: 0x2c, G: 0xa0, B: 0x2c, A: 0xff},
+	{R: 0xff, G: 0x7f, B: 0x0e, A: 0xff},
+	{R: 0x94, G: 0x67, B: 0xbd, A: 0xff},
+	{R: 0x8c, G: 0x56, B: 0x4b, A: 0xff},
+	{R: 0xe3, G: 0x77, B: 0xc2, A: 0xff},
+	{R: 0x17, G: 0xbe, B: 0xcf, A: 0xff},
+}
+
+// Layout constants in pixels
+const (
+	defaultWidth  = 800
+	defaultHeight = 400
+
+	marginLeft   = 60
+	marginRight  = 20
+	marginTop    = 40
+	marginBottom = 40
+	legendRow    = 16
+	tickCount    = 5
+	// minPlotHeight - Smallest plot area, charts grow taller when their legend leaves less
+	minPlotHeight = 100
+)
+
+var (
+	backgroundColor = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	axisColor       = color.RGBA{R: 0x33, G: 0x33, B: 0x33, A: 0xff}
+	gridColor       = color.RGBA{R: 0xe0, G: 0xe0, B: 0xe0, A: 0xff}
+)
+
+// withDefaults - Fills unset size and series colors and makes room for the legend
+func (c Chart) withDefaults() Chart {
+	if c.Width <= 0 {
+		c.Width = defaultWidth
+	}
+	if c.Height <= 0 {
+		c.Height = defaultHeight
+	}
+	if minHeight := marginTop + minPlotHeight + marginBottom + legendRow*len(c.Series); c.Height < minHeight {
+		c.Height = minHeight
+	}
+
+	series := make([]Series, len(c.Series))
+	for i, s := range c.Series {
+		if s.Color.A == 0 {
+			s.Color = Palette[i%len(Palette)]
+		}
+		series[i] = s
+	}
+	c.Series = series
+	return c
+}
+
+// tick - Axis label at a pixel position
+type tick struct {
+	position float64
+	label    string
+}
+
+// layout - Plot area and scales shared by the SVG and PNG renderers
+type layout struct {
+	width, height            float64
+	left, right, top, bottom float64
+	minTime, maxTime         time.Time
+	minValue, maxValue       float64
+	empty                    bool
+	xTicks, yTicks           []tick
+}
+
+func newLayout(c Chart) layout {
+	l := layout{
+		width:  float64(c.Width),
+		height: float64(c.Height),
+		left:   marginLeft,
+		top:    marginTop,
+		empty:  true,
+	}
+	l.right = l.width - marginRight
+	l.bottom = l.height - marginBottom - float64(legendRow*len(c.Series))
+
+	for _, series := range c.Series {
+		for _, point := range series.Points {
+			if l.empty || point.Time.Before(l.minTime) {
+				l.minTime = point.Time
+			}
+			if l.empty || point.Time.After(l.maxTime) {
+				l.maxTime = point.Time
+			}
+			l.minValue = math.Min(l.minValue, point.Value)
+			l.maxValue = math.Max(l.maxValue, point.Value)
+			l.empty = false
+		}
+	}
+
+	if !l.maxTime.After(l.minTime) {
+		l.minTime = l.minTime.Add(-time.Hour)
+		l.maxTime = l.maxTime.Add(time.Hour)
+	}
+
+	step := niceStep(l.maxValue - l.minValue)
+	l.minValue = math.Floor(l.minValue/step) * step
+	l.maxValue = math.Ceil(l.maxValue/step) * step
+	if l.maxValue == l.minValue {
+		l.maxValue += step
+	}
+
+	for value := l.minValue; value <= l.maxValue+step/2; value += step {
+		l.yTicks = append(l.yTicks, tick{position: l.y(value), label: formatValue(value)})
+	}
+
+	span := l.maxTime.Sub(l.minTime)
+	format := "15:04"
+	if span > 24*time.Hour {
+		format = "Jan 02 15:04"
+	}
+	for i := 0; i <= tickCount; i++ {
+		at := l.minTime.Add(span * time.Duration(i) / tickCount)
+		l.xTicks = append(l.xTicks, tick{position: l.x(at), label: at.UTC().Format(format)})
+	}
+	return l
+}
+
+// x - Horizontal pixel of a time
+func (l layout) x(at time.Time) float64 {
+	ratio := float64(at.Sub(l.minTime)) / float64(l.maxTime.Sub(l.minTime))
+	return l.left + ratio*(l.right-l.left)
+}
+
+// y - Vertical pixel of a value
+func (l layout) y(value float64) float64 {
+	ratio := (value - l.minValue) / (l.maxValue - l.minValue)
+	return l.bottom - ratio*(l.bottom-l.top)
+}
+
+// niceStep - Rounds span/tickCount up to 1, 2 or 5 times a power of ten
+func niceStep(span float64) float64 {
+	if span <= 0 {
+		return 1
+	}
+
+	raw := span / tickCount
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, multiple := range []float64{1, 2, 5, 10} {
+		if step := multiple * magnitude; step >= raw {
+			return math.Max(step, 1)
+		}
+	}
+	return 10 * magnitude
+}
+
+func formatValue(value float64) string {
+	return strconv.FormatInt(int64(math.Round(value)), 10)
+}
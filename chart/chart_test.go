@@ -0,0 +1,101 @@
+package chart
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testChart() Chart {
+	start := time.Date(2021, 2, 25, 10, 0, 0, 0, time.UTC)
+
+	upvotes := Series{Name: "Bitcoin <upvotes>"}
+	downvotes := Series{Name: "Bitcoin downvotes"}
+	for i := 0; i < 24; i++ {
+		at := start.Add(time.Duration(i) * time.Hour)
+		upvotes.Points = append(upvotes.Points, Point{Time: at, Value: float64(i * 3)})
+		downvotes.Points = append(downvotes.Points, Point{Time: at, Value: float64(-i)})
+	}
+
+	return Chart{Title: "Bitcoin votes", Series: []Series{upvotes, downvotes}}
+}
+
+func TestNiceStep(t *testing.T) {
+	assert.Equal(t, float64(1), niceStep(0))
+	assert.Equal(t, float64(1), niceStep(3))
+	assert.Equal(t, float64(20), niceStep(92))
+	assert.Equal(t, float64(50), niceStep(180))
+	assert.Equal(t, float64(1000), niceStep(4100))
+}
+
+func TestLayout(t *testing.T) {
+	l := newLayout(testChart().withDefaults())
+
+	assert.False(t, l.empty)
+	assert.Equal(t, float64(-40), l.minValue)
+	assert.Equal(t, float64(80), l.maxValue)
+
+	// Axis ends map to the plot area corners
+	assert.Equal(t, l.left, l.x(l.minTime))
+	assert.Equal(t, l.right, l.x(l.maxTime))
+	assert.Equal(t, l.bottom, l.y(l.minValue))
+	assert.Equal(t, l.top, l.y(l.maxValue))
+}
+
+func TestSVG(t *testing.T) {
+	var out bytes.Buffer
+
+	require.Nil(t, testChart().SVG(&out))
+
+	svg := out.String()
+	assert.True(t, strings.HasPrefix(svg, "<svg "))
+	assert.Equal(t, 2, strings.Count(svg, `stroke-width="2"`))
+	assert.Contains(t, svg, "Bitcoin &lt;upvotes&gt;")
+	assert.Contains(t, svg, "#1f77b4")
+
+	// Test chart without points
+	out.Reset()
+
+	require.Nil(t, Chart{Title: "Empty"}.SVG(&out))
+
+	assert.Contains(t, out.String(), "No snapshots")
+}
+
+func TestLegendGrowsChart(t *testing.T) {
+	c := testChart()
+	for len(c.Series) < 30 {
+		c.Series = append(c.Series, c.Series[0])
+	}
+
+	// Test long legends keep the plot area instead of drawing it upside down
+	l := newLayout(c.withDefaults())
+
+	assert.Equal(t, float64(marginTop+minPlotHeight+marginBottom+legendRow*30), l.height)
+	assert.Equal(t, float64(minPlotHeight), l.bottom-l.top)
+	assert.Less(t, l.y(l.maxValue), l.y(l.minValue))
+}
+
+func TestPNG(t *testing.T) {
+	var out bytes.Buffer
+
+	require.Nil(t, testChart().PNG(&out))
+
+	img, err := png.Decode(&out)
+
+	require.Nil(t, err)
+	assert.Equal(t, defaultWidth, img.Bounds().Dx())
+	assert.Equal(t, defaultHeight, img.Bounds().Dy())
+
+	// Upvote points are drawn with the first palette color
+	l := newLayout(testChart().withDefaults())
+	point := testChart().Series[0].Points[10]
+	r, g, b, a := img.At(int(math.Round(l.x(point.Time))), int(math.Round(l.y(point.Value)))).RGBA()
+	assert.Equal(t, Palette[0], color.RGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: uint8(a >> 8)})
+}
@@ -0,0 +1,107 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// PNG writes the chart as a PNG image
+func (c Chart) PNG(w io.Writer) error {
+	c = c.withDefaults()
+	l := newLayout(c)
+
+	img := image.NewRGBA(image.Rect(0, 0, c.Width, c.Height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: backgroundColor}, image.Point{}, draw.Src)
+
+	drawText(img, c.Title, l.width/2, marginTop/2+5, alignCenter, axisColor)
+
+	for _, t := range l.yTicks {
+		drawLine(img, l.left, t.position, l.right, t.position, 1, gridColor)
+		drawText(img, t.label, l.left-6, t.position+4, alignRight, axisColor)
+	}
+	for _, t := range l.xTicks {
+		drawLine(img, t.position, l.top, t.position, l.bottom, 1, gridColor)
+		drawText(img, t.label, t.position, l.bottom+16, alignCenter, axisColor)
+	}
+	drawLine(img, l.left, l.top, l.left, l.bottom, 1, axisColor)
+	drawLine(img, l.left, l.bottom, l.right, l.bottom, 1, axisColor)
+
+	if l.empty {
+		drawText(img, "No snapshots", (l.left+l.right)/2, (l.top+l.bottom)/2, alignCenter, axisColor)
+	}
+
+	for i, series := range c.Series {
+		for j := 1; j < len(series.Points); j++ {
+			previous, point := series.Points[j-1], series.Points[j]
+			drawLine(img, l.x(previous.Time), l.y(previous.Value), l.x(point.Time), l.y(point.Value), 2, series.Color)
+		}
+		if len(series.Points) == 1 {
+			point := series.Points[0]
+			drawLine(img, l.x(point.Time), l.y(point.Value), l.x(point.Time), l.y(point.Value), 3, series.Color)
+		}
+
+		legendY := l.bottom + 30 + float64(i*legendRow)
+		swatch := image.Rect(int(l.left), int(legendY)-10, int(l.left)+12, int(legendY)+2)
+		draw.Draw(img, swatch, &image.Uniform{C: series.Color}, image.Point{}, draw.Src)
+		drawText(img, series.Name, l.left+18, legendY, alignLeft, axisColor)
+	}
+
+	return png.Encode(w, img)
+}
+
+type alignment int
+
+const (
+	alignLeft alignment = iota
+	alignCenter
+	alignRight
+)
+
+// drawText - Draws text with its baseline at y using the fixed 7x13 font
+func drawText(img draw.Image, text string, x float64, y float64, align alignment, c color.RGBA) {
+	drawer := &font.Drawer{
+		Dst:  img,
+		Src:  &image.Uniform{C: c},
+		Face: basicfont.Face7x13,
+	}
+
+	width := float64(drawer.MeasureString(text).Round())
+	switch align {
+	case alignCenter:
+		x -= width / 2
+	case alignRight:
+		x -= width
+	}
+
+	drawer.Dot = fixed.P(int(math.Round(x)), int(math.Round(y)))
+	drawer.DrawString(text)
+}
+
+// drawLine - Draws a segment with the given thickness by stamping squares along it
+func drawLine(img *image.RGBA, x0 float64, y0 float64, x1 float64, y1 float64, thickness int, c color.RGBA) {
+	steps := int(math.Ceil(math.Max(math.Abs(x1-x0), math.Abs(y1-y0))))
+	if steps == 0 {
+		steps = 1
+	}
+
+	offset := thickness / 2
+	for i := 0; i <= steps; i++ {
+		ratio := float64(i) / float64(steps)
+		x := int(math.Round(x0 + ratio*(x1-x0)))
+		y := int(math.Round(y0 + ratio*(y1-y0)))
+
+		for dx := 0; dx < thickness; dx++ {
+			for dy := 0; dy < thickness; dy++ {
+				img.SetRGBA(x+dx-offset, y+dy-offset, c)
+			}
+		}
+	}
+}
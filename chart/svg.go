@@ -0,0 +1,54 @@
+package chart
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+)
+
+// SVG writes the chart as a standalone SVG document
+func (c Chart) SVG(w io.Writer) error {
+	c = c.withDefaults()
+	l := newLayout(c)
+	out := bufio.NewWriter(w)
+
+	fmt.Fprintf(out, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		c.Width, c.Height, c.Width, c.Height)
+	fmt.Fprintf(out, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", svgColor(backgroundColor))
+	fmt.Fprintf(out, `<text x="%g" y="%d" font-size="16" text-anchor="middle">%s</text>`+"\n", l.width/2, marginTop/2+5, html.EscapeString(c.Title))
+
+	for _, t := range l.yTicks {
+		fmt.Fprintf(out, `<line x1="%g" y1="%.1f" x2="%g" y2="%.1f" stroke="%s"/>`+"\n", l.left, t.position, l.right, t.position, svgColor(gridColor))
+		fmt.Fprintf(out, `<text x="%g" y="%.1f" text-anchor="end">%s</text>`+"\n", l.left-6, t.position+4, t.label)
+	}
+	for _, t := range l.xTicks {
+		fmt.Fprintf(out, `<line x1="%.1f" y1="%g" x2="%.1f" y2="%g" stroke="%s"/>`+"\n", t.position, l.top, t.position, l.bottom, svgColor(gridColor))
+		fmt.Fprintf(out, `<text x="%.1f" y="%g" text-anchor="middle">%s</text>`+"\n", t.position, l.bottom+16, t.label)
+	}
+	fmt.Fprintf(out, `<polyline points="%g,%g %g,%g %g,%g" fill="none" stroke="%s"/>`+"\n", l.left, l.top, l.left, l.bottom, l.right, l.bottom, svgColor(axisColor))
+
+	if l.empty {
+		fmt.Fprintf(out, `<text x="%g" y="%g" text-anchor="middle">No snapshots</text>`+"\n", (l.left+l.right)/2, (l.top+l.bottom)/2)
+	}
+
+	for i, series := range c.Series {
+		fmt.Fprintf(out, `<polyline fill="none" stroke-width="2" stroke="%s" points="`, svgColor(series.Color))
+		for _, point := range series.Points {
+			fmt.Fprintf(out, "%.1f,%.1f ", l.x(point.Time), l.y(point.Value))
+		}
+		fmt.Fprint(out, `"/>`+"\n")
+
+		legendY := l.bottom + 30 + float64(i*legendRow)
+		fmt.Fprintf(out, `<rect x="%g" y="%g" width="12" height="12" fill="%s"/>`+"\n", l.left, legendY-10, svgColor(series.Color))
+		fmt.Fprintf(out, `<text x="%g" y="%g">%s</text>`+"\n", l.left+18, legendY, html.EscapeString(series.Name))
+	}
+
+	fmt.Fprint(out, "</svg>\n")
+	return out.Flush()
+}
+
+func svgColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// credentialHeaders - HTTP headers forwarded to the server as the credentials of the caller
+var credentialHeaders = map[string]string{
+	"Authorization": "authorization",
+	"X-Api-Key":     "x-api-key",
+}
+
+// withCredentials - Adds the credential headers and the address of the HTTP request handled by ctx
+// to the outgoing metadata, the server authenticates and rate limits the REST caller and not the gateway
+func withCredentials(ctx context.Context) context.Context {
+	ginCtx, ok := ctx.(*gin.Context)
+	if !ok {
+		return ctx
+	}
+
+	// The connection address, X-Forwarded-For headers of the caller can't be trusted
+	var pairs []string
+	if host, _, err := net.SplitHostPort(ginCtx.Request.RemoteAddr); err == nil {
+		pairs = append(pairs, "x-forwarded-for", host)
+	}
+	for header, key := range credentialHeaders {
+		if value := ginCtx.GetHeader(header); value != "" {
+			pairs = append(pairs, key, value)
+		}
+	}
+	if len(pairs) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, pairs...)
+}
+
+// forwardCredentialsUnary - Forwards the caller credentials and passes the retry-after trailer of
+// rate limited calls on as the Retry-After header
+func forwardCredentialsUnary(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	var trailer metadata.MD
+	err := invoker(withCredentials(ctx), method, req, reply, cc, append(opts, grpc.Trailer(&trailer))...)
+
+	if ginCtx, ok := ctx.(*gin.Context); ok && status.Code(err) == codes.ResourceExhausted {
+		if retryAfter := trailer.Get("retry-after"); len(retryAfter) > 0 {
+			ginCtx.Header("Retry-After", retryAfter[0])
+		}
+	}
+	return err
+}
+
+func forwardCredentialsStream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(withCredentials(ctx), desc, cc, method, opts...)
+}
+
+// requireCredentials - Answers 401 to requests without credentials instead of forwarding them
+func requireCredentials(ctx *gin.Context) {
+	for header := range credentialHeaders {
+		if ctx.GetHeader(header) != "" {
+			ctx.Next()
+			return
+		}
+	}
+	ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"Error": "Missing credentials"})
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RomuloSiebra/CryptoUpvoteSystem/chart"
+	upvoteSystem "github.com/RomuloSiebra/CryptoUpvoteSystem/proto/UpvoteSystem"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxChartIDs - Most cryptocurrencies drawn in one chart, path id included
+const maxChartIDs = 5
+
+// chartIDs - Returns the path id followed by the comma separated ids query parameter, at most
+// maxChartIDs of them
+func chartIDs(ctx *gin.Context) ([]string, error) {
+	ids := []string{ctx.Param("id")}
+	for _, id := range strings.Split(ctx.Query("ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" && id != ids[0] {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > maxChartIDs {
+		return nil, fmt.Errorf("At most %d cryptocurrencies per chart", maxChartIDs)
+	}
+	return ids, nil
+}
+
+// voteChart - Builds a chart with the upvotes, downvotes and sum series of every crypto
+func voteChart(ctx *gin.Context, client upvoteSystem.UpvoteSystemClient) (chart.Chart, error) {
+	ids, err := chartIDs(ctx)
+	if err != nil {
+		return chart.Chart{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var names []string
+	var series []chart.Series
+
+	for _, id := range ids {
+		request, err := historyRequest(ctx, id)
+		if err != nil {
+			return chart.Chart{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		crypto, err := client.ReadCryptoByID(ctx, &upvoteSystem.ReadCryptoByIDRequest{Id: id})
+		if err != nil {
+			return chart.Chart{}, err
+		}
+
+		history, err := client.GetVoteHistory(ctx, request)
+		if err != nil {
+			return chart.Chart{}, err
+		}
+
+		name := crypto.GetCrypto().GetName()
+		upvotes := chart.Series{Name: name + " upvotes"}
+		downvotes := chart.Series{Name: name + " downvotes"}
+		sum := chart.Series{Name: name + " sum"}
+		for _, snapshot := range history.GetSnapshots() {
+			at := snapshot.GetTimestamp().AsTime()
+			upvotes.Points = append(upvotes.Points, chart.Point{Time: at, Value: float64(snapshot.GetUpvote())})
+			downvotes.Points = append(downvotes.Points, chart.Point{Time: at, Value: float64(snapshot.GetDownvote())})
+			sum.Points = append(sum.Points, chart.Point{Time: at, Value: float64(snapshot.GetSum())})
+		}
+
+		names = append(names, name)
+		series = append(series, upvotes, downvotes, sum)
+	}
+
+	return chart.Chart{Title: strings.Join(names, ", ") + " votes", Series: series}, nil
+}
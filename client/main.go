@@ -1,17 +1,178 @@
 package main
 
 import (
+	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	upvoteSystem "github.com/RomuloSiebra/CryptoUpvoteSystem/proto/UpvoteSystem"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// voterIDHeader - HTTP header forwarded as the voter identity of vote requests
+const voterIDHeader = "X-Voter-Id"
+
+// voteError - Answers the error of a vote or admin call with the HTTP status of its gRPC code
+func voteError(ctx *gin.Context, err error) {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		ctx.JSON(http.StatusBadRequest, gin.H{"Error": status.Convert(err).Message()})
+	case codes.AlreadyExists:
+		ctx.JSON(http.StatusConflict, gin.H{"Error": status.Convert(err).Message()})
+	case codes.NotFound:
+		ctx.JSON(404, gin.H{"Error": status.Convert(err).Message()})
+	case codes.Unauthenticated:
+		ctx.JSON(http.StatusUnauthorized, gin.H{"Error": status.Convert(err).Message()})
+	case codes.PermissionDenied:
+		ctx.JSON(http.StatusForbidden, gin.H{"Error": status.Convert(err).Message()})
+	case codes.ResourceExhausted:
+		ctx.JSON(http.StatusTooManyRequests, gin.H{"Error": status.Convert(err).Message()})
+	default:
+		ctx.JSON(404, gin.H{"Error": "Id not found"})
+	}
+}
+
+// historyRequest - Builds a GetVoteHistoryRequest from the RFC 3339 from and to query
+// parameters and the raw, hourly or daily resolution
+func historyRequest(ctx *gin.Context, id string) (*upvoteSystem.GetVoteHistoryRequest, error) {
+	request := &upvoteSystem.GetVoteHistoryRequest{Id: id}
+
+	if from := ctx.Query("from"); from != "" {
+		fromTime, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid from time")
+		}
+		request.From = timestamppb.New(fromTime)
+	}
+
+	if to := ctx.Query("to"); to != "" {
+		toTime, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid to time")
+		}
+		request.To = timestamppb.New(toTime)
+	}
+
+	if resolution := ctx.Query("resolution"); resolution != "" {
+		value, found := upvoteSystem.HistoryResolution_value["RESOLUTION_"+strings.ToUpper(resolution)]
+		if !found {
+			return nil, fmt.Errorf("Invalid resolution")
+		}
+		request.Resolution = upvoteSystem.HistoryResolution(value)
+	}
+	return request, nil
+}
+
+// defaultPageSize - Page size of GET /crypto when the page_size query parameter is missing
+const defaultPageSize = 100
+
+// listRequest - Builds a ReadAllCryptoRequest from the page_size, page_token, order_by,
+// order (asc or desc), name and description query parameters
+func listRequest(ctx *gin.Context) (*upvoteSystem.ReadAllCryptoRequest, error) {
+	request := &upvoteSystem.ReadAllCryptoRequest{
+		PageSize:          defaultPageSize,
+		PageToken:         ctx.Query("page_token"),
+		NameFilter:        ctx.Query("name"),
+		DescriptionFilter: ctx.Query("description"),
+	}
+
+	if pageSize := ctx.Query("page_size"); pageSize != "" {
+		value, err := strconv.ParseInt(pageSize, 10, 32)
+		if err != nil || value <= 0 {
+			return nil, fmt.Errorf("Invalid page size")
+		}
+		request.PageSize = int32(value)
+	}
+
+	if orderBy := ctx.Query("order_by"); orderBy != "" {
+		value, found := upvoteSystem.CryptoOrder_value["ORDER_"+strings.ToUpper(orderBy)]
+		if !found {
+			return nil, fmt.Errorf("Invalid order")
+		}
+		request.OrderBy = upvoteSystem.CryptoOrder(value)
+	}
+
+	switch ctx.Query("order") {
+	case "", "asc":
+	case "desc":
+		request.Descending = true
+	default:
+		return nil, fmt.Errorf("Invalid order direction")
+	}
+	return request, nil
+}
+
+// voteDirection - Parses the direction query parameter of vote changes, up or down
+func voteDirection(direction string) (upvoteSystem.VoteDirection, error) {
+	switch strings.ToLower(direction) {
+	case "up":
+		return upvoteSystem.VoteDirection_VOTE_UP, nil
+	case "down":
+		return upvoteSystem.VoteDirection_VOTE_DOWN, nil
+	}
+	return upvoteSystem.VoteDirection_VOTE_NONE, fmt.Errorf("Invalid vote direction")
+}
+
+// leaderboardRequest - Builds a GetLeaderboardRequest from the metric (net_votes, upvotes,
+// downvotes, wilson, hot or controversial) and limit query parameters
+func leaderboardRequest(ctx *gin.Context) (*upvoteSystem.GetLeaderboardRequest, error) {
+	request := &upvoteSystem.GetLeaderboardRequest{}
+
+	if metric := ctx.Query("metric"); metric != "" {
+		value, found := upvoteSystem.LeaderboardMetric_value["METRIC_"+strings.ToUpper(metric)]
+		if !found {
+			return nil, fmt.Errorf("Invalid metric")
+		}
+		request.Metric = upvoteSystem.LeaderboardMetric(value)
+	}
+
+	if limit := ctx.Query("limit"); limit != "" {
+		value, err := strconv.ParseInt(limit, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid limit")
+		}
+		request.Limit = int32(value)
+	}
+	return request, nil
+}
+
+// searchCrypto - Serves GET /cryptoSearch, searching the q query parameter with an optional limit
+func searchCrypto(ctx *gin.Context, client upvoteSystem.UpvoteSystemClient) {
+	request := &upvoteSystem.SearchCryptoRequest{Query: ctx.Query("q")}
+
+	if limit := ctx.Query("limit"); limit != "" {
+		value, err := strconv.ParseInt(limit, 10, 32)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"Error": "Invalid limit"})
+			return
+		}
+		request.Limit = int32(value)
+	}
+
+	resp, err := client.SearchCrypto(ctx, request)
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			ctx.JSON(http.StatusBadRequest, gin.H{"Error": status.Convert(err).Message()})
+			return
+		}
+		ctx.JSON(http.StatusBadGateway, gin.H{"Error": "Couldn`t search cryptocurrencies"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"result": resp,
+	})
+}
+
 func main() {
 
 	err := godotenv.Load(".env")
@@ -29,12 +190,16 @@ func main() {
 		log.Fatal("Error: Invalid CLIENT_PORT environment variable")
 	}
 
-	conn, err := grpc.Dial("localhost:"+serverPort, grpc.WithInsecure())
+	conn, err := grpc.Dial("localhost:"+serverPort, grpcTransport(),
+		grpc.WithUnaryInterceptor(forwardCredentialsUnary), grpc.WithStreamInterceptor(forwardCredentialsStream))
 	if err != nil {
 		panic(err)
 	}
 	client := upvoteSystem.NewUpvoteSystemClient(conn)
 	g := gin.Default()
+	if os.Getenv("AUTH_DISABLED") != "true" {
+		g.Use(requireCredentials)
+	}
 
 	g.POST("/crypto", func(ctx *gin.Context) {
 
@@ -51,7 +216,7 @@ func main() {
 
 		result, err := client.CreateCrypto(ctx, request)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"Error": "Invalid request body"})
+			voteError(ctx, err)
 			return
 		}
 		ctx.JSON(http.StatusCreated, gin.H{
@@ -61,34 +226,40 @@ func main() {
 	})
 
 	g.GET("/crypto", func(ctx *gin.Context) {
-		request := &upvoteSystem.ReadAllCryptoRequest{}
+		request, err := listRequest(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"Error": err.Error()})
+			return
+		}
 
 		stream, err := client.ReadAllCrypto(ctx, request)
 		if err != nil {
 			ctx.JSON(http.StatusBadGateway, gin.H{"Error": "Couldn`t get cryptocurrencies"})
+			return
 		}
 
 		var result []*upvoteSystem.ReadAllCryptoResponse
-		done := make(chan bool)
-
-		go func() {
-			for {
-				resp, err := stream.Recv()
-				if err == io.EOF {
-					done <- true
-					return
-				}
-				if err != nil {
-					ctx.JSON(http.StatusBadGateway, gin.H{"Error": "Couldn`t get cryptocurrencies"})
-				}
-				result = append(result, resp)
-
+		var nextPageToken string
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if status.Code(err) == codes.InvalidArgument {
+				ctx.JSON(http.StatusBadRequest, gin.H{"Error": status.Convert(err).Message()})
+				return
+			}
+			if err != nil {
+				ctx.JSON(http.StatusBadGateway, gin.H{"Error": "Couldn`t get cryptocurrencies"})
+				return
 			}
-		}()
+			result = append(result, resp)
+			nextPageToken = resp.GetNextPageToken()
+		}
 
-		<-done
 		ctx.JSON(http.StatusOK, gin.H{
-			"result": result,
+			"result":        result,
+			"nextPageToken": nextPageToken,
 		})
 
 	})
@@ -111,6 +282,51 @@ func main() {
 
 	})
 
+	g.GET("/crypto/:id/history", func(ctx *gin.Context) {
+		request, err := historyRequest(ctx, ctx.Param("id"))
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"Error": err.Error()})
+			return
+		}
+
+		resp, err := client.GetVoteHistory(ctx, request)
+		if err != nil {
+			voteError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{
+			"result": resp,
+		})
+
+	})
+
+	g.GET("/crypto/:id/chart.svg", func(ctx *gin.Context) {
+		votes, err := voteChart(ctx, client)
+		if err != nil {
+			voteError(ctx, err)
+			return
+		}
+
+		ctx.Header("Content-Type", "image/svg+xml")
+		if err := votes.SVG(ctx.Writer); err != nil {
+			log.Printf("Failed to write chart: %v", err)
+		}
+	})
+
+	g.GET("/crypto/:id/chart.png", func(ctx *gin.Context) {
+		votes, err := voteChart(ctx, client)
+		if err != nil {
+			voteError(ctx, err)
+			return
+		}
+
+		ctx.Header("Content-Type", "image/png")
+		if err := votes.PNG(ctx.Writer); err != nil {
+			log.Printf("Failed to write chart: %v", err)
+		}
+	})
+
 	g.DELETE("/crypto/:id", func(ctx *gin.Context) {
 
 		id := ctx.Param("id")
@@ -120,7 +336,7 @@ func main() {
 
 		resp, err := client.DeleteCrypto(ctx, request)
 		if err != nil {
-			ctx.JSON(404, gin.H{"Error": "Id not found"})
+			voteError(ctx, err)
 			return
 		}
 
@@ -143,7 +359,7 @@ func main() {
 
 		result, err := client.UpdateCrypto(ctx, request)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"Error": "Invalid request body"})
+			voteError(ctx, err)
 			return
 		}
 		ctx.JSON(http.StatusOK, gin.H{
@@ -153,13 +369,21 @@ func main() {
 
 	g.POST("/crypto/upvote/:id", func(ctx *gin.Context) {
 		id := ctx.Param("id")
+		signature, err := voteSignature(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"Error": "Invalid vote signature"})
+			return
+		}
+
 		request := &upvoteSystem.UpvoteCryptoRequest{
-			Id: id,
+			Id:        id,
+			VoterId:   ctx.GetHeader(voterIDHeader),
+			Signature: signature,
 		}
 
 		resp, err := client.UpvoteCrypto(ctx, request)
 		if err != nil {
-			ctx.JSON(404, gin.H{"Error": "Id not found"})
+			voteError(ctx, err)
 			return
 		}
 		ctx.JSON(http.StatusOK, gin.H{
@@ -170,13 +394,77 @@ func main() {
 
 	g.POST("/crypto/downvote/:id", func(ctx *gin.Context) {
 		id := ctx.Param("id")
+		signature, err := voteSignature(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"Error": "Invalid vote signature"})
+			return
+		}
+
 		request := &upvoteSystem.DownvoteCryptoRequest{
-			Id: id,
+			Id:        id,
+			VoterId:   ctx.GetHeader(voterIDHeader),
+			Signature: signature,
 		}
 
 		resp, err := client.DownvoteCrypto(ctx, request)
 		if err != nil {
-			ctx.JSON(404, gin.H{"Error": "Id not found"})
+			voteError(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{
+			"result": resp,
+		})
+
+	})
+	g.DELETE("/cryptoVote/:id", func(ctx *gin.Context) {
+		id := ctx.Param("id")
+		signature, err := voteSignature(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"Error": "Invalid vote signature"})
+			return
+		}
+
+		request := &upvoteSystem.RetractVoteRequest{
+			Id:        id,
+			VoterId:   ctx.GetHeader(voterIDHeader),
+			Signature: signature,
+		}
+
+		resp, err := client.RetractVote(ctx, request)
+		if err != nil {
+			voteError(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{
+			"result": resp,
+		})
+
+	})
+
+	g.PUT("/cryptoVote/:id", func(ctx *gin.Context) {
+		id := ctx.Param("id")
+
+		direction, err := voteDirection(ctx.Query("direction"))
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"Error": err.Error()})
+			return
+		}
+		signature, err := voteSignature(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"Error": "Invalid vote signature"})
+			return
+		}
+
+		request := &upvoteSystem.ChangeVoteRequest{
+			Id:        id,
+			VoterId:   ctx.GetHeader(voterIDHeader),
+			Direction: direction,
+			Signature: signature,
+		}
+
+		resp, err := client.ChangeVote(ctx, request)
+		if err != nil {
+			voteError(ctx, err)
 			return
 		}
 		ctx.JSON(http.StatusOK, gin.H{
@@ -184,6 +472,7 @@ func main() {
 		})
 
 	})
+
 	g.GET("/cryptoSum/:id", func(ctx *gin.Context) {
 		id := ctx.Param("id")
 		request := &upvoteSystem.GetVotesSumRequest{
@@ -200,7 +489,43 @@ func main() {
 		})
 	})
 
-	if err := g.Run(":" + clientPort); err != nil {
+	g.GET("/cryptoSearch", func(ctx *gin.Context) {
+		searchCrypto(ctx, client)
+	})
+
+	g.GET("/cryptoSymbol/:symbol", func(ctx *gin.Context) {
+		request := &upvoteSystem.ReadCryptoBySymbolRequest{
+			Symbol: ctx.Param("symbol"),
+		}
+
+		resp, err := client.ReadCryptoBySymbol(ctx, request)
+		if err != nil {
+			ctx.JSON(404, gin.H{"Error": "Symbol not found"})
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{
+			"result": resp,
+		})
+	})
+
+	g.GET("/leaderboard", func(ctx *gin.Context) {
+		request, err := leaderboardRequest(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"Error": err.Error()})
+			return
+		}
+
+		resp, err := client.GetLeaderboard(ctx, request)
+		if err != nil {
+			voteError(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{
+			"result": resp,
+		})
+	})
+
+	if err := serve(g, clientPort); err != nil {
 		log.Fatalf("Failed to run server: %v", err)
 	}
 }
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	upvoteSystem "github.com/RomuloSiebra/CryptoUpvoteSystem/proto/UpvoteSystem"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// voteSignatureBody - Optional JSON body of vote requests signed by a wallet, keys and signatures
+// are hex encoded
+type voteSignatureBody struct {
+	// KeyType - ed25519 or secp256k1
+	KeyType   string    `json:"keyType"`
+	PublicKey string    `json:"publicKey"`
+	Nonce     string    `json:"nonce"`
+	Timestamp time.Time `json:"timestamp"`
+	Signature string    `json:"signature"`
+}
+
+// voteSignature - Signature of the body of a vote request, nil for requests without body
+func voteSignature(ctx *gin.Context) (*upvoteSystem.VoteSignature, error) {
+	var body voteSignatureBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	keyType, found := upvoteSystem.KeyType_value["KEY_"+strings.ToUpper(body.KeyType)]
+	if !found || keyType == int32(upvoteSystem.KeyType_KEY_UNSPECIFIED) {
+		return nil, errors.New("invalid key type")
+	}
+	publicKey, err := hex.DecodeString(body.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := hex.DecodeString(body.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return &upvoteSystem.VoteSignature{
+		KeyType:   upvoteSystem.KeyType(keyType),
+		PublicKey: publicKey,
+		Nonce:     body.Nonce,
+		Timestamp: timestamppb.New(body.Timestamp),
+		Signature: signature,
+	}, nil
+}
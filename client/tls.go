@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/RomuloSiebra/CryptoUpvoteSystem/tlsreload"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// reloadInterval - Time between checks of the certificate files, from TLS_RELOAD_INTERVAL
+func reloadInterval() time.Duration {
+	value := os.Getenv("TLS_RELOAD_INTERVAL")
+	if value == "" {
+		return tlsreload.DefaultInterval
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 {
+		log.Fatal("Error: Invalid TLS_RELOAD_INTERVAL environment variable")
+	}
+	return interval
+}
+
+// grpcTransport - TLS credentials verifying the server against GATEWAY_GRPC_CA_FILE, or the system
+// roots, and presenting GATEWAY_GRPC_CERT_FILE for mutual TLS. Plaintext when none of them is set
+func grpcTransport() grpc.DialOption {
+	certFile, keyFile, caFile := os.Getenv("GATEWAY_GRPC_CERT_FILE"), os.Getenv("GATEWAY_GRPC_KEY_FILE"), os.Getenv("GATEWAY_GRPC_CA_FILE")
+	if certFile == "" && caFile == "" {
+		return grpc.WithInsecure()
+	}
+
+	certificates, err := tlsreload.New(certFile, keyFile, caFile)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	go certificates.Run(context.Background(), reloadInterval())
+
+	serverName := os.Getenv("GATEWAY_GRPC_SERVER_NAME")
+	if serverName == "" {
+		serverName = "localhost"
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(certificates.ClientConfig(serverName)))
+}
+
+// serve - Serves handler on port, over HTTPS with GATEWAY_HTTPS_CERT_FILE and GATEWAY_HTTPS_KEY_FILE
+func serve(handler http.Handler, port string) error {
+	certFile := os.Getenv("GATEWAY_HTTPS_CERT_FILE")
+	if certFile == "" {
+		return http.ListenAndServe(":"+port, handler)
+	}
+
+	certificates, err := tlsreload.New(certFile, os.Getenv("GATEWAY_HTTPS_KEY_FILE"), "")
+	if err != nil {
+		return err
+	}
+	go certificates.Run(context.Background(), reloadInterval())
+
+	server := &http.Server{
+		Addr:      ":" + port,
+		Handler:   handler,
+		TLSConfig: certificates.ServerConfig("h2", "http/1.1"),
+	}
+	return server.ListenAndServeTLS("", "")
+}
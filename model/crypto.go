@@ -1,12 +1,22 @@
 package model
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
 
 // Crypto - Cryptocurrency MongoDB model
 type Crypto struct {
 	ID          primitive.ObjectID `json:"id" bson:"_id"`
 	Name        string             `json:"name" bson:"name"`
 	Description string             `json:"description" bson:"description"`
+	Symbol      string             `json:"symbol,omitempty" bson:"symbol,omitempty"` // ticker, empty when unknown
+	Website     string             `json:"website,omitempty" bson:"website,omitempty"`
+	LogoURL     string             `json:"logoUrl,omitempty" bson:"logo_url,omitempty"`
+	LaunchDate  time.Time          `json:"launchDate,omitempty" bson:"launch_date,omitempty"`
+	Tags        []string           `json:"tags,omitempty" bson:"tags,omitempty"`
 	Upvote      int32              `json:"upvote" bson:"upvote"`
 	Downvote    int32              `json:"downvote" bson:"downvote"`
+	LastVoteAt  time.Time          `json:"lastVoteAt" bson:"last_vote_at,omitempty"` // zero until the first vote
 }
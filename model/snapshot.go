@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Snapshot - Vote counters of a cryptocurrency recorded by the snapshot job
+type Snapshot struct {
+	CryptoID  primitive.ObjectID `json:"cryptoId" bson:"crypto_id"`
+	Upvote    int32              `json:"upvote" bson:"upvote"`
+	Downvote  int32              `json:"downvote" bson:"downvote"`
+	Sum       int32              `json:"sum" bson:"sum"`
+	Timestamp time.Time          `json:"timestamp" bson:"timestamp"`
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// VoteDirection - Direction of a voter vote, NoVote means the voter has no active vote
+type VoteDirection int32
+
+// Possible vote directions
+const (
+	NoVote   VoteDirection = 0
+	Upvote   VoteDirection = 1
+	Downvote VoteDirection = -1
+)
+
+// Vote - Active vote of one voter on one cryptocurrency
+type Vote struct {
+	CryptoID  primitive.ObjectID `json:"cryptoId" bson:"crypto_id"`
+	VoterID   string             `json:"voterId" bson:"voter_id"`
+	Direction VoteDirection      `json:"direction" bson:"direction"`
+	UpdatedAt time.Time          `json:"updatedAt" bson:"updated_at"`
+}
@@ -9,6 +9,8 @@ package UpvoteSystem
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -20,16 +22,358 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type CryptoOrder int32
+
+const (
+	// Creation time
+	CryptoOrder_ORDER_CREATED  CryptoOrder = 0
+	CryptoOrder_ORDER_NAME     CryptoOrder = 1
+	CryptoOrder_ORDER_UPVOTE   CryptoOrder = 2
+	CryptoOrder_ORDER_DOWNVOTE CryptoOrder = 3
+	// Upvotes minus downvotes
+	CryptoOrder_ORDER_NET_VOTES CryptoOrder = 4
+	// Lower bound of the Wilson score confidence interval of the upvote ratio
+	CryptoOrder_ORDER_WILSON CryptoOrder = 5
+	// Net votes decayed by the time since the latest vote
+	CryptoOrder_ORDER_HOT CryptoOrder = 6
+	// Many votes evenly split between upvotes and downvotes
+	CryptoOrder_ORDER_CONTROVERSIAL CryptoOrder = 7
+)
+
+// Enum value maps for CryptoOrder.
+var (
+	CryptoOrder_name = map[int32]string{
+		0: "ORDER_CREATED",
+		1: "ORDER_NAME",
+		2: "ORDER_UPVOTE",
+		3: "ORDER_DOWNVOTE",
+		4: "ORDER_NET_VOTES",
+		5: "ORDER_WILSON",
+		6: "ORDER_HOT",
+		7: "ORDER_CONTROVERSIAL",
+	}
+	CryptoOrder_value = map[string]int32{
+		"ORDER_CREATED":       0,
+		"ORDER_NAME":          1,
+		"ORDER_UPVOTE":        2,
+		"ORDER_DOWNVOTE":      3,
+		"ORDER_NET_VOTES":     4,
+		"ORDER_WILSON":        5,
+		"ORDER_HOT":           6,
+		"ORDER_CONTROVERSIAL": 7,
+	}
+)
+
+func (x CryptoOrder) Enum() *CryptoOrder {
+	p := new(CryptoOrder)
+	*p = x
+	return p
+}
+
+func (x CryptoOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CryptoOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_UpvoteSystem_proto_enumTypes[0].Descriptor()
+}
+
+func (CryptoOrder) Type() protoreflect.EnumType {
+	return &file_proto_UpvoteSystem_proto_enumTypes[0]
+}
+
+func (x CryptoOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CryptoOrder.Descriptor instead.
+func (CryptoOrder) EnumDescriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{0}
+}
+
+type VoteDirection int32
+
+const (
+	VoteDirection_VOTE_NONE VoteDirection = 0
+	VoteDirection_VOTE_UP   VoteDirection = 1
+	VoteDirection_VOTE_DOWN VoteDirection = 2
+)
+
+// Enum value maps for VoteDirection.
+var (
+	VoteDirection_name = map[int32]string{
+		0: "VOTE_NONE",
+		1: "VOTE_UP",
+		2: "VOTE_DOWN",
+	}
+	VoteDirection_value = map[string]int32{
+		"VOTE_NONE": 0,
+		"VOTE_UP":   1,
+		"VOTE_DOWN": 2,
+	}
+)
+
+func (x VoteDirection) Enum() *VoteDirection {
+	p := new(VoteDirection)
+	*p = x
+	return p
+}
+
+func (x VoteDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VoteDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_UpvoteSystem_proto_enumTypes[1].Descriptor()
+}
+
+func (VoteDirection) Type() protoreflect.EnumType {
+	return &file_proto_UpvoteSystem_proto_enumTypes[1]
+}
+
+func (x VoteDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VoteDirection.Descriptor instead.
+func (VoteDirection) EnumDescriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{1}
+}
+
+type KeyType int32
+
+const (
+	KeyType_KEY_UNSPECIFIED KeyType = 0
+	KeyType_KEY_ED25519     KeyType = 1
+	KeyType_KEY_SECP256K1   KeyType = 2
+)
+
+// Enum value maps for KeyType.
+var (
+	KeyType_name = map[int32]string{
+		0: "KEY_UNSPECIFIED",
+		1: "KEY_ED25519",
+		2: "KEY_SECP256K1",
+	}
+	KeyType_value = map[string]int32{
+		"KEY_UNSPECIFIED": 0,
+		"KEY_ED25519":     1,
+		"KEY_SECP256K1":   2,
+	}
+)
+
+func (x KeyType) Enum() *KeyType {
+	p := new(KeyType)
+	*p = x
+	return p
+}
+
+func (x KeyType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (KeyType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_UpvoteSystem_proto_enumTypes[2].Descriptor()
+}
+
+func (KeyType) Type() protoreflect.EnumType {
+	return &file_proto_UpvoteSystem_proto_enumTypes[2]
+}
+
+func (x KeyType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use KeyType.Descriptor instead.
+func (KeyType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{2}
+}
+
+type HistoryResolution int32
+
+const (
+	// Every recorded snapshot
+	HistoryResolution_RESOLUTION_RAW HistoryResolution = 0
+	// Last snapshot of each hour
+	HistoryResolution_RESOLUTION_HOURLY HistoryResolution = 1
+	// Last snapshot of each day
+	HistoryResolution_RESOLUTION_DAILY HistoryResolution = 2
+)
+
+// Enum value maps for HistoryResolution.
+var (
+	HistoryResolution_name = map[int32]string{
+		0: "RESOLUTION_RAW",
+		1: "RESOLUTION_HOURLY",
+		2: "RESOLUTION_DAILY",
+	}
+	HistoryResolution_value = map[string]int32{
+		"RESOLUTION_RAW":    0,
+		"RESOLUTION_HOURLY": 1,
+		"RESOLUTION_DAILY":  2,
+	}
+)
+
+func (x HistoryResolution) Enum() *HistoryResolution {
+	p := new(HistoryResolution)
+	*p = x
+	return p
+}
+
+func (x HistoryResolution) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HistoryResolution) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_UpvoteSystem_proto_enumTypes[3].Descriptor()
+}
+
+func (HistoryResolution) Type() protoreflect.EnumType {
+	return &file_proto_UpvoteSystem_proto_enumTypes[3]
+}
+
+func (x HistoryResolution) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HistoryResolution.Descriptor instead.
+func (HistoryResolution) EnumDescriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{3}
+}
+
+type CryptoEventType int32
+
+const (
+	CryptoEventType_EVENT_NONE    CryptoEventType = 0
+	CryptoEventType_EVENT_CREATED CryptoEventType = 1
+	CryptoEventType_EVENT_UPDATED CryptoEventType = 2
+	CryptoEventType_EVENT_DELETED CryptoEventType = 3
+	CryptoEventType_EVENT_VOTED   CryptoEventType = 4
+)
+
+// Enum value maps for CryptoEventType.
+var (
+	CryptoEventType_name = map[int32]string{
+		0: "EVENT_NONE",
+		1: "EVENT_CREATED",
+		2: "EVENT_UPDATED",
+		3: "EVENT_DELETED",
+		4: "EVENT_VOTED",
+	}
+	CryptoEventType_value = map[string]int32{
+		"EVENT_NONE":    0,
+		"EVENT_CREATED": 1,
+		"EVENT_UPDATED": 2,
+		"EVENT_DELETED": 3,
+		"EVENT_VOTED":   4,
+	}
+)
+
+func (x CryptoEventType) Enum() *CryptoEventType {
+	p := new(CryptoEventType)
+	*p = x
+	return p
+}
+
+func (x CryptoEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CryptoEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_UpvoteSystem_proto_enumTypes[4].Descriptor()
+}
+
+func (CryptoEventType) Type() protoreflect.EnumType {
+	return &file_proto_UpvoteSystem_proto_enumTypes[4]
+}
+
+func (x CryptoEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CryptoEventType.Descriptor instead.
+func (CryptoEventType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{4}
+}
+
+type LeaderboardMetric int32
+
+const (
+	// Upvotes minus downvotes
+	LeaderboardMetric_METRIC_NET_VOTES     LeaderboardMetric = 0
+	LeaderboardMetric_METRIC_UPVOTES       LeaderboardMetric = 1
+	LeaderboardMetric_METRIC_DOWNVOTES     LeaderboardMetric = 2
+	LeaderboardMetric_METRIC_WILSON        LeaderboardMetric = 3
+	LeaderboardMetric_METRIC_HOT           LeaderboardMetric = 4
+	LeaderboardMetric_METRIC_CONTROVERSIAL LeaderboardMetric = 5
+)
+
+// Enum value maps for LeaderboardMetric.
+var (
+	LeaderboardMetric_name = map[int32]string{
+		0: "METRIC_NET_VOTES",
+		1: "METRIC_UPVOTES",
+		2: "METRIC_DOWNVOTES",
+		3: "METRIC_WILSON",
+		4: "METRIC_HOT",
+		5: "METRIC_CONTROVERSIAL",
+	}
+	LeaderboardMetric_value = map[string]int32{
+		"METRIC_NET_VOTES":     0,
+		"METRIC_UPVOTES":       1,
+		"METRIC_DOWNVOTES":     2,
+		"METRIC_WILSON":        3,
+		"METRIC_HOT":           4,
+		"METRIC_CONTROVERSIAL": 5,
+	}
+)
+
+func (x LeaderboardMetric) Enum() *LeaderboardMetric {
+	p := new(LeaderboardMetric)
+	*p = x
+	return p
+}
+
+func (x LeaderboardMetric) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LeaderboardMetric) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_UpvoteSystem_proto_enumTypes[5].Descriptor()
+}
+
+func (LeaderboardMetric) Type() protoreflect.EnumType {
+	return &file_proto_UpvoteSystem_proto_enumTypes[5]
+}
+
+func (x LeaderboardMetric) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LeaderboardMetric.Descriptor instead.
+func (LeaderboardMetric) EnumDescriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{5}
+}
+
 type Cryptocurrency struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Unique ignoring case
 	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	Upvote      int32  `protobuf:"varint,3,opt,name=upvote,proto3" json:"upvote,omitempty"`
 	Downvote    int32  `protobuf:"varint,4,opt,name=downvote,proto3" json:"downvote,omitempty"`
 	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	// Ticker, unique ignoring case and stored in upper case, empty when unknown
+	Symbol string `protobuf:"bytes,6,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// Absolute http or https URLs
+	Website    string                 `protobuf:"bytes,7,opt,name=website,proto3" json:"website,omitempty"`
+	LogoUrl    string                 `protobuf:"bytes,8,opt,name=logo_url,json=logoUrl,proto3" json:"logo_url,omitempty"`
+	LaunchDate *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=launch_date,json=launchDate,proto3" json:"launch_date,omitempty"`
+	// Stored in lower case without duplicates
+	Tags []string `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
 }
 
 func (x *Cryptocurrency) Reset() {
@@ -99,6 +443,41 @@ func (x *Cryptocurrency) GetDescription() string {
 	return ""
 }
 
+func (x *Cryptocurrency) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Cryptocurrency) GetWebsite() string {
+	if x != nil {
+		return x.Website
+	}
+	return ""
+}
+
+func (x *Cryptocurrency) GetLogoUrl() string {
+	if x != nil {
+		return x.LogoUrl
+	}
+	return ""
+}
+
+func (x *Cryptocurrency) GetLaunchDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LaunchDate
+	}
+	return nil
+}
+
+func (x *Cryptocurrency) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
 type CreateCryptoRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -381,14 +760,17 @@ func (x *ReadCryptoByIDResponse) GetCrypto() *Cryptocurrency {
 	return nil
 }
 
-type ReadAllCryptoRequest struct {
+type ReadCryptoBySymbolRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// Matched ignoring case
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
 }
 
-func (x *ReadAllCryptoRequest) Reset() {
-	*x = ReadAllCryptoRequest{}
+func (x *ReadCryptoBySymbolRequest) Reset() {
+	*x = ReadCryptoBySymbolRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_UpvoteSystem_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -396,13 +778,13 @@ func (x *ReadAllCryptoRequest) Reset() {
 	}
 }
 
-func (x *ReadAllCryptoRequest) String() string {
+func (x *ReadCryptoBySymbolRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReadAllCryptoRequest) ProtoMessage() {}
+func (*ReadCryptoBySymbolRequest) ProtoMessage() {}
 
-func (x *ReadAllCryptoRequest) ProtoReflect() protoreflect.Message {
+func (x *ReadCryptoBySymbolRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_UpvoteSystem_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -414,12 +796,19 @@ func (x *ReadAllCryptoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReadAllCryptoRequest.ProtoReflect.Descriptor instead.
-func (*ReadAllCryptoRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReadCryptoBySymbolRequest.ProtoReflect.Descriptor instead.
+func (*ReadCryptoBySymbolRequest) Descriptor() ([]byte, []int) {
 	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{7}
 }
 
-type ReadAllCryptoResponse struct {
+func (x *ReadCryptoBySymbolRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+type ReadCryptoBySymbolResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -427,8 +816,8 @@ type ReadAllCryptoResponse struct {
 	Crypto *Cryptocurrency `protobuf:"bytes,1,opt,name=crypto,proto3" json:"crypto,omitempty"`
 }
 
-func (x *ReadAllCryptoResponse) Reset() {
-	*x = ReadAllCryptoResponse{}
+func (x *ReadCryptoBySymbolResponse) Reset() {
+	*x = ReadCryptoBySymbolResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_UpvoteSystem_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -436,13 +825,13 @@ func (x *ReadAllCryptoResponse) Reset() {
 	}
 }
 
-func (x *ReadAllCryptoResponse) String() string {
+func (x *ReadCryptoBySymbolResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReadAllCryptoResponse) ProtoMessage() {}
+func (*ReadCryptoBySymbolResponse) ProtoMessage() {}
 
-func (x *ReadAllCryptoResponse) ProtoReflect() protoreflect.Message {
+func (x *ReadCryptoBySymbolResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_UpvoteSystem_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -454,28 +843,36 @@ func (x *ReadAllCryptoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReadAllCryptoResponse.ProtoReflect.Descriptor instead.
-func (*ReadAllCryptoResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReadCryptoBySymbolResponse.ProtoReflect.Descriptor instead.
+func (*ReadCryptoBySymbolResponse) Descriptor() ([]byte, []int) {
 	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ReadAllCryptoResponse) GetCrypto() *Cryptocurrency {
+func (x *ReadCryptoBySymbolResponse) GetCrypto() *Cryptocurrency {
 	if x != nil {
 		return x.Crypto
 	}
 	return nil
 }
 
-type UpdateCryptoRequest struct {
+type ReadAllCryptoRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Crypto *Cryptocurrency `protobuf:"bytes,1,opt,name=crypto,proto3" json:"crypto,omitempty"`
+	// Zero streams every cryptocurrency, larger values are capped at 1000
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page, requested with the same order
+	PageToken  string      `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	OrderBy    CryptoOrder `protobuf:"varint,3,opt,name=order_by,json=orderBy,proto3,enum=UpvoteSystem.CryptoOrder" json:"order_by,omitempty"`
+	Descending bool        `protobuf:"varint,4,opt,name=descending,proto3" json:"descending,omitempty"`
+	// Keep cryptocurrencies whose name or description contain the filter, ignoring case
+	NameFilter        string `protobuf:"bytes,5,opt,name=name_filter,json=nameFilter,proto3" json:"name_filter,omitempty"`
+	DescriptionFilter string `protobuf:"bytes,6,opt,name=description_filter,json=descriptionFilter,proto3" json:"description_filter,omitempty"`
 }
 
-func (x *UpdateCryptoRequest) Reset() {
-	*x = UpdateCryptoRequest{}
+func (x *ReadAllCryptoRequest) Reset() {
+	*x = ReadAllCryptoRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_UpvoteSystem_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -483,13 +880,13 @@ func (x *UpdateCryptoRequest) Reset() {
 	}
 }
 
-func (x *UpdateCryptoRequest) String() string {
+func (x *ReadAllCryptoRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateCryptoRequest) ProtoMessage() {}
+func (*ReadAllCryptoRequest) ProtoMessage() {}
 
-func (x *UpdateCryptoRequest) ProtoReflect() protoreflect.Message {
+func (x *ReadAllCryptoRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_UpvoteSystem_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -501,28 +898,65 @@ func (x *UpdateCryptoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateCryptoRequest.ProtoReflect.Descriptor instead.
-func (*UpdateCryptoRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReadAllCryptoRequest.ProtoReflect.Descriptor instead.
+func (*ReadAllCryptoRequest) Descriptor() ([]byte, []int) {
 	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *UpdateCryptoRequest) GetCrypto() *Cryptocurrency {
+func (x *ReadAllCryptoRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.Crypto
+		return x.PageSize
 	}
-	return nil
+	return 0
 }
 
-type UpdateCryptoResponse struct {
+func (x *ReadAllCryptoRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ReadAllCryptoRequest) GetOrderBy() CryptoOrder {
+	if x != nil {
+		return x.OrderBy
+	}
+	return CryptoOrder_ORDER_CREATED
+}
+
+func (x *ReadAllCryptoRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *ReadAllCryptoRequest) GetNameFilter() string {
+	if x != nil {
+		return x.NameFilter
+	}
+	return ""
+}
+
+func (x *ReadAllCryptoRequest) GetDescriptionFilter() string {
+	if x != nil {
+		return x.DescriptionFilter
+	}
+	return ""
+}
+
+type ReadAllCryptoResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Crypto *Cryptocurrency `protobuf:"bytes,1,opt,name=crypto,proto3" json:"crypto,omitempty"`
+	// Set on the last cryptocurrency of a page when more follow
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 }
 
-func (x *UpdateCryptoResponse) Reset() {
-	*x = UpdateCryptoResponse{}
+func (x *ReadAllCryptoResponse) Reset() {
+	*x = ReadAllCryptoResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_UpvoteSystem_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -530,13 +964,13 @@ func (x *UpdateCryptoResponse) Reset() {
 	}
 }
 
-func (x *UpdateCryptoResponse) String() string {
+func (x *ReadAllCryptoResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateCryptoResponse) ProtoMessage() {}
+func (*ReadAllCryptoResponse) ProtoMessage() {}
 
-func (x *UpdateCryptoResponse) ProtoReflect() protoreflect.Message {
+func (x *ReadAllCryptoResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_UpvoteSystem_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -548,35 +982,140 @@ func (x *UpdateCryptoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateCryptoResponse.ProtoReflect.Descriptor instead.
-func (*UpdateCryptoResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReadAllCryptoResponse.ProtoReflect.Descriptor instead.
+func (*ReadAllCryptoResponse) Descriptor() ([]byte, []int) {
 	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *UpdateCryptoResponse) GetCrypto() *Cryptocurrency {
+func (x *ReadAllCryptoResponse) GetCrypto() *Cryptocurrency {
 	if x != nil {
 		return x.Crypto
 	}
 	return nil
 }
 
-type UpvoteCryptoRequest struct {
+func (x *ReadAllCryptoResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type UpdateCryptoRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Crypto *Cryptocurrency `protobuf:"bytes,1,opt,name=crypto,proto3" json:"crypto,omitempty"`
 }
 
-func (x *UpvoteCryptoRequest) Reset() {
-	*x = UpvoteCryptoRequest{}
-	if protoimpl.UnsafeEnabled {
+func (x *UpdateCryptoRequest) Reset() {
+	*x = UpdateCryptoRequest{}
+	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_UpvoteSystem_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
+func (x *UpdateCryptoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCryptoRequest) ProtoMessage() {}
+
+func (x *UpdateCryptoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCryptoRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCryptoRequest) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateCryptoRequest) GetCrypto() *Cryptocurrency {
+	if x != nil {
+		return x.Crypto
+	}
+	return nil
+}
+
+type UpdateCryptoResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Crypto *Cryptocurrency `protobuf:"bytes,1,opt,name=crypto,proto3" json:"crypto,omitempty"`
+}
+
+func (x *UpdateCryptoResponse) Reset() {
+	*x = UpdateCryptoResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateCryptoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCryptoResponse) ProtoMessage() {}
+
+func (x *UpdateCryptoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCryptoResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCryptoResponse) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateCryptoResponse) GetCrypto() *Cryptocurrency {
+	if x != nil {
+		return x.Crypto
+	}
+	return nil
+}
+
+type UpvoteCryptoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Falls back to the voter-id request metadata when empty
+	VoterId string `protobuf:"bytes,2,opt,name=voter_id,json=voterId,proto3" json:"voter_id,omitempty"`
+	// Signed votes are cast by the signing key, voter_id must then be empty or its voter ID
+	Signature *VoteSignature `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *UpvoteCryptoRequest) Reset() {
+	*x = UpvoteCryptoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
 func (x *UpvoteCryptoRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
@@ -584,7 +1123,7 @@ func (x *UpvoteCryptoRequest) String() string {
 func (*UpvoteCryptoRequest) ProtoMessage() {}
 
 func (x *UpvoteCryptoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_UpvoteSystem_proto_msgTypes[11]
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -597,7 +1136,7 @@ func (x *UpvoteCryptoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpvoteCryptoRequest.ProtoReflect.Descriptor instead.
 func (*UpvoteCryptoRequest) Descriptor() ([]byte, []int) {
-	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{11}
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *UpvoteCryptoRequest) GetId() string {
@@ -607,6 +1146,20 @@ func (x *UpvoteCryptoRequest) GetId() string {
 	return ""
 }
 
+func (x *UpvoteCryptoRequest) GetVoterId() string {
+	if x != nil {
+		return x.VoterId
+	}
+	return ""
+}
+
+func (x *UpvoteCryptoRequest) GetSignature() *VoteSignature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
 type UpvoteCryptoResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -618,7 +1171,7 @@ type UpvoteCryptoResponse struct {
 func (x *UpvoteCryptoResponse) Reset() {
 	*x = UpvoteCryptoResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_UpvoteSystem_proto_msgTypes[12]
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -631,7 +1184,7 @@ func (x *UpvoteCryptoResponse) String() string {
 func (*UpvoteCryptoResponse) ProtoMessage() {}
 
 func (x *UpvoteCryptoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_UpvoteSystem_proto_msgTypes[12]
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -644,7 +1197,7 @@ func (x *UpvoteCryptoResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpvoteCryptoResponse.ProtoReflect.Descriptor instead.
 func (*UpvoteCryptoResponse) Descriptor() ([]byte, []int) {
-	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{12}
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *UpvoteCryptoResponse) GetCrypto() *Cryptocurrency {
@@ -660,12 +1213,16 @@ type DownvoteCryptoRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Falls back to the voter-id request metadata when empty
+	VoterId string `protobuf:"bytes,2,opt,name=voter_id,json=voterId,proto3" json:"voter_id,omitempty"`
+	// Signed votes are cast by the signing key, voter_id must then be empty or its voter ID
+	Signature *VoteSignature `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
 func (x *DownvoteCryptoRequest) Reset() {
 	*x = DownvoteCryptoRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_UpvoteSystem_proto_msgTypes[13]
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -678,7 +1235,7 @@ func (x *DownvoteCryptoRequest) String() string {
 func (*DownvoteCryptoRequest) ProtoMessage() {}
 
 func (x *DownvoteCryptoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_UpvoteSystem_proto_msgTypes[13]
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -691,7 +1248,7 @@ func (x *DownvoteCryptoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DownvoteCryptoRequest.ProtoReflect.Descriptor instead.
 func (*DownvoteCryptoRequest) Descriptor() ([]byte, []int) {
-	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{13}
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *DownvoteCryptoRequest) GetId() string {
@@ -701,6 +1258,20 @@ func (x *DownvoteCryptoRequest) GetId() string {
 	return ""
 }
 
+func (x *DownvoteCryptoRequest) GetVoterId() string {
+	if x != nil {
+		return x.VoterId
+	}
+	return ""
+}
+
+func (x *DownvoteCryptoRequest) GetSignature() *VoteSignature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
 type DownvoteCryptoResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -712,7 +1283,7 @@ type DownvoteCryptoResponse struct {
 func (x *DownvoteCryptoResponse) Reset() {
 	*x = DownvoteCryptoResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_UpvoteSystem_proto_msgTypes[14]
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -725,7 +1296,7 @@ func (x *DownvoteCryptoResponse) String() string {
 func (*DownvoteCryptoResponse) ProtoMessage() {}
 
 func (x *DownvoteCryptoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_UpvoteSystem_proto_msgTypes[14]
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -738,7 +1309,7 @@ func (x *DownvoteCryptoResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DownvoteCryptoResponse.ProtoReflect.Descriptor instead.
 func (*DownvoteCryptoResponse) Descriptor() ([]byte, []int) {
-	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{14}
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *DownvoteCryptoResponse) GetCrypto() *Cryptocurrency {
@@ -748,31 +1319,49 @@ func (x *DownvoteCryptoResponse) GetCrypto() *Cryptocurrency {
 	return nil
 }
 
-type GetVotesSumRequest struct {
+// Wallet signature of a vote, the public key is the voter identity. The signed message is the UTF-8
+// text of these lines joined by a newline:
+//
+//	upvote-system-vote:v1
+//	<cryptocurrency id>
+//	<direction: up, down or none>
+//	<nonce>
+//	<timestamp in RFC 3339 UTC, as many fractional digits as needed>
+//
+// Ed25519 keys sign the message itself, secp256k1 keys sign its SHA-256 hash
+type VoteSignature struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	KeyType KeyType `protobuf:"varint,1,opt,name=key_type,json=keyType,proto3,enum=UpvoteSystem.KeyType" json:"key_type,omitempty"`
+	// 32 bytes for Ed25519, a compressed or uncompressed SEC 1 point for secp256k1
+	PublicKey []byte `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// Up to 128 letters, digits, '-' or '_', never reused by the key
+	Nonce string `protobuf:"bytes,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// Within 5 minutes of the server clock
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// 64 bytes, r followed by s as big endian integers for secp256k1
+	Signature []byte `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
-func (x *GetVotesSumRequest) Reset() {
-	*x = GetVotesSumRequest{}
+func (x *VoteSignature) Reset() {
+	*x = VoteSignature{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_UpvoteSystem_proto_msgTypes[15]
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetVotesSumRequest) String() string {
+func (x *VoteSignature) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetVotesSumRequest) ProtoMessage() {}
+func (*VoteSignature) ProtoMessage() {}
 
-func (x *GetVotesSumRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_UpvoteSystem_proto_msgTypes[15]
+func (x *VoteSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -783,43 +1372,75 @@ func (x *GetVotesSumRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetVotesSumRequest.ProtoReflect.Descriptor instead.
-func (*GetVotesSumRequest) Descriptor() ([]byte, []int) {
-	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use VoteSignature.ProtoReflect.Descriptor instead.
+func (*VoteSignature) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *GetVotesSumRequest) GetId() string {
+func (x *VoteSignature) GetKeyType() KeyType {
 	if x != nil {
-		return x.Id
+		return x.KeyType
+	}
+	return KeyType_KEY_UNSPECIFIED
+}
+
+func (x *VoteSignature) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *VoteSignature) GetNonce() string {
+	if x != nil {
+		return x.Nonce
 	}
 	return ""
 }
 
-type GetVotesSumResponse struct {
+func (x *VoteSignature) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *VoteSignature) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type RetractVoteRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Votes int32 `protobuf:"varint,1,opt,name=votes,proto3" json:"votes,omitempty"`
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Falls back to the voter-id request metadata when empty
+	VoterId string `protobuf:"bytes,2,opt,name=voter_id,json=voterId,proto3" json:"voter_id,omitempty"`
+	// Signed votes are cast by the signing key, voter_id must then be empty or its voter ID
+	Signature *VoteSignature `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
-func (x *GetVotesSumResponse) Reset() {
-	*x = GetVotesSumResponse{}
+func (x *RetractVoteRequest) Reset() {
+	*x = RetractVoteRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_UpvoteSystem_proto_msgTypes[16]
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetVotesSumResponse) String() string {
+func (x *RetractVoteRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetVotesSumResponse) ProtoMessage() {}
+func (*RetractVoteRequest) ProtoMessage() {}
 
-func (x *GetVotesSumResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_UpvoteSystem_proto_msgTypes[16]
+func (x *RetractVoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -830,43 +1451,57 @@ func (x *GetVotesSumResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetVotesSumResponse.ProtoReflect.Descriptor instead.
-func (*GetVotesSumResponse) Descriptor() ([]byte, []int) {
-	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use RetractVoteRequest.ProtoReflect.Descriptor instead.
+func (*RetractVoteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *GetVotesSumResponse) GetVotes() int32 {
+func (x *RetractVoteRequest) GetId() string {
 	if x != nil {
-		return x.Votes
+		return x.Id
 	}
-	return 0
+	return ""
 }
 
-type GetVoteSumStreamRequest struct {
+func (x *RetractVoteRequest) GetVoterId() string {
+	if x != nil {
+		return x.VoterId
+	}
+	return ""
+}
+
+func (x *RetractVoteRequest) GetSignature() *VoteSignature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type RetractVoteResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Crypto *Cryptocurrency `protobuf:"bytes,1,opt,name=crypto,proto3" json:"crypto,omitempty"`
 }
 
-func (x *GetVoteSumStreamRequest) Reset() {
-	*x = GetVoteSumStreamRequest{}
+func (x *RetractVoteResponse) Reset() {
+	*x = RetractVoteResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_UpvoteSystem_proto_msgTypes[17]
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetVoteSumStreamRequest) String() string {
+func (x *RetractVoteResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetVoteSumStreamRequest) ProtoMessage() {}
+func (*RetractVoteResponse) ProtoMessage() {}
 
-func (x *GetVoteSumStreamRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_UpvoteSystem_proto_msgTypes[17]
+func (x *RetractVoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -877,43 +1512,48 @@ func (x *GetVoteSumStreamRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetVoteSumStreamRequest.ProtoReflect.Descriptor instead.
-func (*GetVoteSumStreamRequest) Descriptor() ([]byte, []int) {
-	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use RetractVoteResponse.ProtoReflect.Descriptor instead.
+func (*RetractVoteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *GetVoteSumStreamRequest) GetId() string {
+func (x *RetractVoteResponse) GetCrypto() *Cryptocurrency {
 	if x != nil {
-		return x.Id
+		return x.Crypto
 	}
-	return ""
+	return nil
 }
 
-type GetVoteSumStreamResponse struct {
+type ChangeVoteRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Votes int32 `protobuf:"varint,1,opt,name=votes,proto3" json:"votes,omitempty"`
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Falls back to the voter-id request metadata when empty
+	VoterId   string        `protobuf:"bytes,2,opt,name=voter_id,json=voterId,proto3" json:"voter_id,omitempty"`
+	Direction VoteDirection `protobuf:"varint,3,opt,name=direction,proto3,enum=UpvoteSystem.VoteDirection" json:"direction,omitempty"`
+	// Signed votes are cast by the signing key, voter_id must then be empty or its voter ID
+	Signature *VoteSignature `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
-func (x *GetVoteSumStreamResponse) Reset() {
-	*x = GetVoteSumStreamResponse{}
+func (x *ChangeVoteRequest) Reset() {
+	*x = ChangeVoteRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_UpvoteSystem_proto_msgTypes[18]
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetVoteSumStreamResponse) String() string {
+func (x *ChangeVoteRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetVoteSumStreamResponse) ProtoMessage() {}
+func (*ChangeVoteRequest) ProtoMessage() {}
 
-func (x *GetVoteSumStreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_UpvoteSystem_proto_msgTypes[18]
+func (x *ChangeVoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -924,138 +1564,1679 @@ func (x *GetVoteSumStreamResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetVoteSumStreamResponse.ProtoReflect.Descriptor instead.
-func (*GetVoteSumStreamResponse) Descriptor() ([]byte, []int) {
-	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use ChangeVoteRequest.ProtoReflect.Descriptor instead.
+func (*ChangeVoteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *GetVoteSumStreamResponse) GetVotes() int32 {
+func (x *ChangeVoteRequest) GetId() string {
 	if x != nil {
-		return x.Votes
+		return x.Id
 	}
-	return 0
+	return ""
 }
 
-var File_proto_UpvoteSystem_proto protoreflect.FileDescriptor
+func (x *ChangeVoteRequest) GetVoterId() string {
+	if x != nil {
+		return x.VoterId
+	}
+	return ""
+}
 
-var file_proto_UpvoteSystem_proto_rawDesc = []byte{
-	0x0a, 0x18, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79,
-	0x73, 0x74, 0x65, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x55, 0x70, 0x76, 0x6f,
-	0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x22, 0x8a, 0x01, 0x0a, 0x0e, 0x43, 0x72, 0x79,
-	0x70, 0x74, 0x6f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x16, 0x0a, 0x06, 0x75, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x06, 0x75, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x6f, 0x77, 0x6e, 0x76,
-	0x6f, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x64, 0x6f, 0x77, 0x6e, 0x76,
-	0x6f, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x4b, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43,
-	0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x06,
-	0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x55,
-	0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x43, 0x72, 0x79, 0x70,
-	0x74, 0x6f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x06, 0x63, 0x72, 0x79, 0x70,
-	0x74, 0x6f, 0x22, 0x4c, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70,
-	0x74, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x06, 0x63, 0x72,
-	0x79, 0x70, 0x74, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x55, 0x70, 0x76,
-	0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f,
-	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f,
-	0x22, 0x25, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x30, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74,
-	0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x27, 0x0a, 0x15, 0x52, 0x65, 0x61,
-	0x64, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
-	0x69, 0x64, 0x22, 0x4e, 0x0a, 0x16, 0x52, 0x65, 0x61, 0x64, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f,
-	0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x06,
-	0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x55,
-	0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x43, 0x72, 0x79, 0x70,
-	0x74, 0x6f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x06, 0x63, 0x72, 0x79, 0x70,
-	0x74, 0x6f, 0x22, 0x16, 0x0a, 0x14, 0x52, 0x65, 0x61, 0x64, 0x41, 0x6c, 0x6c, 0x43, 0x72, 0x79,
-	0x70, 0x74, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x4d, 0x0a, 0x15, 0x52, 0x65,
-	0x61, 0x64, 0x41, 0x6c, 0x6c, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74,
-	0x65, 0x6d, 0x2e, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
-	0x79, 0x52, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x22, 0x4b, 0x0a, 0x13, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x34, 0x0a, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1c, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e,
-	0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x06,
-	0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x22, 0x4c, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+func (x *ChangeVoteRequest) GetDirection() VoteDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return VoteDirection_VOTE_NONE
+}
+
+func (x *ChangeVoteRequest) GetSignature() *VoteSignature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type ChangeVoteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Crypto *Cryptocurrency `protobuf:"bytes,1,opt,name=crypto,proto3" json:"crypto,omitempty"`
+}
+
+func (x *ChangeVoteResponse) Reset() {
+	*x = ChangeVoteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangeVoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeVoteResponse) ProtoMessage() {}
+
+func (x *ChangeVoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeVoteResponse.ProtoReflect.Descriptor instead.
+func (*ChangeVoteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ChangeVoteResponse) GetCrypto() *Cryptocurrency {
+	if x != nil {
+		return x.Crypto
+	}
+	return nil
+}
+
+type VoteCommand struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Echoed in the acknowledgement of the command
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Id        string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Falls back to the voter-id stream metadata when empty
+	VoterId string `protobuf:"bytes,3,opt,name=voter_id,json=voterId,proto3" json:"voter_id,omitempty"`
+	// VOTE_UP or VOTE_DOWN cast the vote like UpvoteCrypto and DownvoteCrypto, VOTE_NONE retracts it
+	Direction VoteDirection `protobuf:"varint,4,opt,name=direction,proto3,enum=UpvoteSystem.VoteDirection" json:"direction,omitempty"`
+	// Signed votes are cast by the signing key, voter_id must then be empty or its voter ID
+	Signature *VoteSignature `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *VoteCommand) Reset() {
+	*x = VoteCommand{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VoteCommand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoteCommand) ProtoMessage() {}
+
+func (x *VoteCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoteCommand.ProtoReflect.Descriptor instead.
+func (*VoteCommand) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *VoteCommand) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *VoteCommand) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *VoteCommand) GetVoterId() string {
+	if x != nil {
+		return x.VoterId
+	}
+	return ""
+}
+
+func (x *VoteCommand) GetDirection() VoteDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return VoteDirection_VOTE_NONE
+}
+
+func (x *VoteCommand) GetSignature() *VoteSignature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type VoteAck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// Counters after the vote, unset when the command failed
+	Crypto *Cryptocurrency `protobuf:"bytes,2,opt,name=crypto,proto3" json:"crypto,omitempty"`
+	// gRPC status code of the command, zero when it succeeded
+	Code    uint32 `protobuf:"varint,3,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *VoteAck) Reset() {
+	*x = VoteAck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VoteAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoteAck) ProtoMessage() {}
+
+func (x *VoteAck) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoteAck.ProtoReflect.Descriptor instead.
+func (*VoteAck) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *VoteAck) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *VoteAck) GetCrypto() *Cryptocurrency {
+	if x != nil {
+		return x.Crypto
+	}
+	return nil
+}
+
+func (x *VoteAck) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *VoteAck) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetVotesSumRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetVotesSumRequest) Reset() {
+	*x = GetVotesSumRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVotesSumRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVotesSumRequest) ProtoMessage() {}
+
+func (x *GetVotesSumRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVotesSumRequest.ProtoReflect.Descriptor instead.
+func (*GetVotesSumRequest) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetVotesSumRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetVotesSumResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Votes int32 `protobuf:"varint,1,opt,name=votes,proto3" json:"votes,omitempty"`
+}
+
+func (x *GetVotesSumResponse) Reset() {
+	*x = GetVotesSumResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVotesSumResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVotesSumResponse) ProtoMessage() {}
+
+func (x *GetVotesSumResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVotesSumResponse.ProtoReflect.Descriptor instead.
+func (*GetVotesSumResponse) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetVotesSumResponse) GetVotes() int32 {
+	if x != nil {
+		return x.Votes
+	}
+	return 0
+}
+
+type VoteSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Upvote    int32                  `protobuf:"varint,2,opt,name=upvote,proto3" json:"upvote,omitempty"`
+	Downvote  int32                  `protobuf:"varint,3,opt,name=downvote,proto3" json:"downvote,omitempty"`
+	Sum       int32                  `protobuf:"varint,4,opt,name=sum,proto3" json:"sum,omitempty"`
+}
+
+func (x *VoteSnapshot) Reset() {
+	*x = VoteSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VoteSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoteSnapshot) ProtoMessage() {}
+
+func (x *VoteSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoteSnapshot.ProtoReflect.Descriptor instead.
+func (*VoteSnapshot) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *VoteSnapshot) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *VoteSnapshot) GetUpvote() int32 {
+	if x != nil {
+		return x.Upvote
+	}
+	return 0
+}
+
+func (x *VoteSnapshot) GetDownvote() int32 {
+	if x != nil {
+		return x.Downvote
+	}
+	return 0
+}
+
+func (x *VoteSnapshot) GetSum() int32 {
+	if x != nil {
+		return x.Sum
+	}
+	return 0
+}
+
+type GetVoteHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Unset from starts at the first snapshot and unset to ends now
+	From       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Resolution HistoryResolution      `protobuf:"varint,4,opt,name=resolution,proto3,enum=UpvoteSystem.HistoryResolution" json:"resolution,omitempty"`
+}
+
+func (x *GetVoteHistoryRequest) Reset() {
+	*x = GetVoteHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVoteHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVoteHistoryRequest) ProtoMessage() {}
+
+func (x *GetVoteHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVoteHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetVoteHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetVoteHistoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetVoteHistoryRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetVoteHistoryRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetVoteHistoryRequest) GetResolution() HistoryResolution {
+	if x != nil {
+		return x.Resolution
+	}
+	return HistoryResolution_RESOLUTION_RAW
+}
+
+type GetVoteHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Snapshots []*VoteSnapshot `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+}
+
+func (x *GetVoteHistoryResponse) Reset() {
+	*x = GetVoteHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVoteHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVoteHistoryResponse) ProtoMessage() {}
+
+func (x *GetVoteHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVoteHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetVoteHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetVoteHistoryResponse) GetSnapshots() []*VoteSnapshot {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
+type GetVoteSumStreamRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Interval of heartbeat messages, unset or zero disables them
+	HeartbeatInterval *durationpb.Duration `protobuf:"bytes,2,opt,name=heartbeat_interval,json=heartbeatInterval,proto3" json:"heartbeat_interval,omitempty"`
+	// Sequence of the last message received before reconnecting, the missed changes are sent
+	// first when they are still retained
+	ResumeFromSequence uint64 `protobuf:"varint,3,opt,name=resume_from_sequence,json=resumeFromSequence,proto3" json:"resume_from_sequence,omitempty"`
+	// Epoch of the responses carrying resume_from_sequence, a sequence of another epoch is not
+	// resumed and the stream starts with a snapshot
+	ResumeEpoch uint64 `protobuf:"varint,4,opt,name=resume_epoch,json=resumeEpoch,proto3" json:"resume_epoch,omitempty"`
+}
+
+func (x *GetVoteSumStreamRequest) Reset() {
+	*x = GetVoteSumStreamRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVoteSumStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVoteSumStreamRequest) ProtoMessage() {}
+
+func (x *GetVoteSumStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVoteSumStreamRequest.ProtoReflect.Descriptor instead.
+func (*GetVoteSumStreamRequest) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetVoteSumStreamRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetVoteSumStreamRequest) GetHeartbeatInterval() *durationpb.Duration {
+	if x != nil {
+		return x.HeartbeatInterval
+	}
+	return nil
+}
+
+func (x *GetVoteSumStreamRequest) GetResumeFromSequence() uint64 {
+	if x != nil {
+		return x.ResumeFromSequence
+	}
+	return 0
+}
+
+func (x *GetVoteSumStreamRequest) GetResumeEpoch() uint64 {
+	if x != nil {
+		return x.ResumeEpoch
+	}
+	return 0
+}
+
+type GetVoteSumStreamResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Votes int32 `protobuf:"varint,1,opt,name=votes,proto3" json:"votes,omitempty"`
+	// Number of changes published for the cryptocurrency, heartbeats repeat the latest one
+	Sequence  uint64                 `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Heartbeat bool                   `protobuf:"varint,4,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`
+	// Current sum sent when the stream opens without resuming or the missed changes are no longer retained
+	Snapshot bool `protobuf:"varint,5,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	// Server instance numbering the sequences, they start over with a new epoch after a restart or failover
+	Epoch uint64 `protobuf:"varint,6,opt,name=epoch,proto3" json:"epoch,omitempty"`
+}
+
+func (x *GetVoteSumStreamResponse) Reset() {
+	*x = GetVoteSumStreamResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVoteSumStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVoteSumStreamResponse) ProtoMessage() {}
+
+func (x *GetVoteSumStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVoteSumStreamResponse.ProtoReflect.Descriptor instead.
+func (*GetVoteSumStreamResponse) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetVoteSumStreamResponse) GetVotes() int32 {
+	if x != nil {
+		return x.Votes
+	}
+	return 0
+}
+
+func (x *GetVoteSumStreamResponse) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *GetVoteSumStreamResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *GetVoteSumStreamResponse) GetHeartbeat() bool {
+	if x != nil {
+		return x.Heartbeat
+	}
+	return false
+}
+
+func (x *GetVoteSumStreamResponse) GetSnapshot() bool {
+	if x != nil {
+		return x.Snapshot
+	}
+	return false
+}
+
+func (x *GetVoteSumStreamResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+type WatchCryptosRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Cryptocurrencies to watch, empty watches every cryptocurrency
+	Ids []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *WatchCryptosRequest) Reset() {
+	*x = WatchCryptosRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchCryptosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchCryptosRequest) ProtoMessage() {}
+
+func (x *WatchCryptosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchCryptosRequest.ProtoReflect.Descriptor instead.
+func (*WatchCryptosRequest) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *WatchCryptosRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type WatchCryptosResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type CryptoEventType `protobuf:"varint,1,opt,name=type,proto3,enum=UpvoteSystem.CryptoEventType" json:"type,omitempty"`
+	// Deleted events only carry the id
+	Crypto *Cryptocurrency `protobuf:"bytes,2,opt,name=crypto,proto3" json:"crypto,omitempty"`
+	// Number of events published for the cryptocurrency
+	Sequence  uint64                 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *WatchCryptosResponse) Reset() {
+	*x = WatchCryptosResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchCryptosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchCryptosResponse) ProtoMessage() {}
+
+func (x *WatchCryptosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchCryptosResponse.ProtoReflect.Descriptor instead.
+func (*WatchCryptosResponse) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *WatchCryptosResponse) GetType() CryptoEventType {
+	if x != nil {
+		return x.Type
+	}
+	return CryptoEventType_EVENT_NONE
+}
+
+func (x *WatchCryptosResponse) GetCrypto() *Cryptocurrency {
+	if x != nil {
+		return x.Crypto
+	}
+	return nil
+}
+
+func (x *WatchCryptosResponse) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *WatchCryptosResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type GetLeaderboardRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metric LeaderboardMetric `protobuf:"varint,1,opt,name=metric,proto3,enum=UpvoteSystem.LeaderboardMetric" json:"metric,omitempty"`
+	// Number of ranked cryptocurrencies, unset or zero ranks 10 and at most 100 are ranked
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *GetLeaderboardRequest) Reset() {
+	*x = GetLeaderboardRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderboardRequest) ProtoMessage() {}
+
+func (x *GetLeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetLeaderboardRequest) GetMetric() LeaderboardMetric {
+	if x != nil {
+		return x.Metric
+	}
+	return LeaderboardMetric_METRIC_NET_VOTES
+}
+
+func (x *GetLeaderboardRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type LeaderboardEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Cryptocurrencies with the same score share the rank of the first of them
+	Rank   uint32          `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	Crypto *Cryptocurrency `protobuf:"bytes,2,opt,name=crypto,proto3" json:"crypto,omitempty"`
+	Score  float64         `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+}
+
+func (x *LeaderboardEntry) Reset() {
+	*x = LeaderboardEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeaderboardEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardEntry) ProtoMessage() {}
+
+func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardEntry.ProtoReflect.Descriptor instead.
+func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *LeaderboardEntry) GetRank() uint32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetCrypto() *Cryptocurrency {
+	if x != nil {
+		return x.Crypto
+	}
+	return nil
+}
+
+func (x *LeaderboardEntry) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type GetLeaderboardResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries []*LeaderboardEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *GetLeaderboardResponse) Reset() {
+	*x = GetLeaderboardResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLeaderboardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderboardResponse) ProtoMessage() {}
+
+func (x *GetLeaderboardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderboardResponse.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardResponse) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetLeaderboardResponse) GetEntries() []*LeaderboardEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type RankChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Zero previous_rank entered the board and zero rank left it
+	PreviousRank uint32 `protobuf:"varint,2,opt,name=previous_rank,json=previousRank,proto3" json:"previous_rank,omitempty"`
+	Rank         uint32 `protobuf:"varint,3,opt,name=rank,proto3" json:"rank,omitempty"`
+}
+
+func (x *RankChange) Reset() {
+	*x = RankChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RankChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankChange) ProtoMessage() {}
+
+func (x *RankChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankChange.ProtoReflect.Descriptor instead.
+func (*RankChange) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RankChange) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RankChange) GetPreviousRank() uint32 {
+	if x != nil {
+		return x.PreviousRank
+	}
+	return 0
+}
+
+func (x *RankChange) GetRank() uint32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+type WatchLeaderboardResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whole board after the changes
+	Entries []*LeaderboardEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Empty on the first message
+	Changes   []*RankChange          `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *WatchLeaderboardResponse) Reset() {
+	*x = WatchLeaderboardResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchLeaderboardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchLeaderboardResponse) ProtoMessage() {}
+
+func (x *WatchLeaderboardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchLeaderboardResponse.ProtoReflect.Descriptor instead.
+func (*WatchLeaderboardResponse) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *WatchLeaderboardResponse) GetEntries() []*LeaderboardEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *WatchLeaderboardResponse) GetChanges() []*RankChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *WatchLeaderboardResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type SearchCryptoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Words matched case-insensitively against names and descriptions, whole, as prefixes or with one typo
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Number of results, unset or zero returns 20 and at most 100 are returned
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *SearchCryptoRequest) Reset() {
+	*x = SearchCryptoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchCryptoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchCryptoRequest) ProtoMessage() {}
+
+func (x *SearchCryptoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchCryptoRequest.ProtoReflect.Descriptor instead.
+func (*SearchCryptoRequest) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SearchCryptoRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchCryptoRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Crypto *Cryptocurrency `protobuf:"bytes,1,opt,name=crypto,proto3" json:"crypto,omitempty"`
+	// Higher is more relevant, name matches weigh more than description ones
+	Relevance float64 `protobuf:"fixed64,2,opt,name=relevance,proto3" json:"relevance,omitempty"`
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SearchResult) GetCrypto() *Cryptocurrency {
+	if x != nil {
+		return x.Crypto
+	}
+	return nil
+}
+
+func (x *SearchResult) GetRelevance() float64 {
+	if x != nil {
+		return x.Relevance
+	}
+	return 0
+}
+
+type SearchCryptoResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*SearchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *SearchCryptoResponse) Reset() {
+	*x = SearchCryptoResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_UpvoteSystem_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchCryptoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchCryptoResponse) ProtoMessage() {}
+
+func (x *SearchCryptoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_UpvoteSystem_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchCryptoResponse.ProtoReflect.Descriptor instead.
+func (*SearchCryptoResponse) Descriptor() ([]byte, []int) {
+	return file_proto_UpvoteSystem_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SearchCryptoResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+var File_proto_UpvoteSystem_proto protoreflect.FileDescriptor
+
+var file_proto_UpvoteSystem_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x55, 0x70, 0x76, 0x6f,
+	0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa8, 0x02, 0x0a, 0x0e, 0x43, 0x72,
+	0x79, 0x70, 0x74, 0x6f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x75, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x75, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x6f, 0x77, 0x6e,
+	0x76, 0x6f, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x64, 0x6f, 0x77, 0x6e,
+	0x76, 0x6f, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x18,
+	0x0a, 0x07, 0x77, 0x65, 0x62, 0x73, 0x69, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x77, 0x65, 0x62, 0x73, 0x69, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x6f, 0x67, 0x6f,
+	0x5f, 0x75, 0x72, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x6f, 0x67, 0x6f,
+	0x55, 0x72, 0x6c, 0x12, 0x3b, 0x0a, 0x0b, 0x6c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x5f, 0x64, 0x61,
+	0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x44, 0x61, 0x74, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x61, 0x67, 0x73, 0x22, 0x4b, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x72,
+	0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x06, 0x63,
+	0x72, 0x79, 0x70, 0x74, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x55, 0x70,
+	0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x43, 0x72, 0x79, 0x70, 0x74,
+	0x6f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74,
+	0x6f, 0x22, 0x4c, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74,
+	0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x06, 0x63, 0x72, 0x79,
+	0x70, 0x74, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x55, 0x70, 0x76, 0x6f,
+	0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x22,
+	0x25, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x30, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x27, 0x0a, 0x15, 0x52, 0x65, 0x61, 0x64,
+	0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x4e, 0x0a, 0x16, 0x52, 0x65, 0x61, 0x64, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x42,
+	0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x06, 0x63,
+	0x72, 0x79, 0x70, 0x74, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x55, 0x70,
+	0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x43, 0x72, 0x79, 0x70, 0x74,
+	0x6f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74,
+	0x6f, 0x22, 0x33, 0x0a, 0x19, 0x52, 0x65, 0x61, 0x64, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x42,
+	0x79, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x22, 0x52, 0x0a, 0x1a, 0x52, 0x65, 0x61, 0x64, 0x43, 0x72,
+	0x79, 0x70, 0x74, 0x6f, 0x42, 0x79, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x2e, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x63, 0x79, 0x52, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x22, 0xf8, 0x01, 0x0a, 0x14, 0x52,
+	0x65, 0x61, 0x64, 0x41, 0x6c, 0x6c, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x34, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x62, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x19, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x2e, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x07, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x42, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x61, 0x6d, 0x65,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x2d, 0x0a, 0x12, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x11, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x75, 0x0a, 0x15, 0x52, 0x65, 0x61, 0x64, 0x41, 0x6c, 0x6c,
 	0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34,
 	0x0a, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
 	0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x43, 0x72,
 	0x79, 0x70, 0x74, 0x6f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x06, 0x63, 0x72,
-	0x79, 0x70, 0x74, 0x6f, 0x22, 0x25, 0x0a, 0x13, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x43, 0x72,
-	0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x4c, 0x0a, 0x14, 0x55,
-	0x70, 0x76, 0x6f, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x18, 0x01, 0x20,
+	0x79, 0x70, 0x74, 0x6f, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e,
+	0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x4b, 0x0a, 0x13,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74,
 	0x65, 0x6d, 0x2e, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
-	0x79, 0x52, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x22, 0x27, 0x0a, 0x15, 0x44, 0x6f, 0x77,
-	0x6e, 0x76, 0x6f, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
-	0x69, 0x64, 0x22, 0x4e, 0x0a, 0x16, 0x44, 0x6f, 0x77, 0x6e, 0x76, 0x6f, 0x74, 0x65, 0x43, 0x72,
+	0x79, 0x52, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x22, 0x4c, 0x0a, 0x14, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x34, 0x0a, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x2e, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x52,
+	0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x22, 0x7b, 0x0a, 0x13, 0x55, 0x70, 0x76, 0x6f, 0x74,
+	0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x19,
+	0x0a, 0x08, 0x76, 0x6f, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x76, 0x6f, 0x74, 0x65, 0x72, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x09, 0x73, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x55,
+	0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x56, 0x6f, 0x74, 0x65,
+	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x22, 0x4c, 0x0a, 0x14, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x43, 0x72,
 	0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x06,
 	0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x55,
 	0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x43, 0x72, 0x79, 0x70,
 	0x74, 0x6f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x06, 0x63, 0x72, 0x79, 0x70,
-	0x74, 0x6f, 0x22, 0x24, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x53, 0x75,
+	0x74, 0x6f, 0x22, 0x7d, 0x0a, 0x15, 0x44, 0x6f, 0x77, 0x6e, 0x76, 0x6f, 0x74, 0x65, 0x43, 0x72,
+	0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x76,
+	0x6f, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76,
+	0x6f, 0x74, 0x65, 0x72, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x55, 0x70, 0x76, 0x6f,
+	0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x53, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x22, 0x4e, 0x0a, 0x16, 0x44, 0x6f, 0x77, 0x6e, 0x76, 0x6f, 0x74, 0x65, 0x43, 0x72, 0x79,
+	0x70, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x06, 0x63,
+	0x72, 0x79, 0x70, 0x74, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x55, 0x70,
+	0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x43, 0x72, 0x79, 0x70, 0x74,
+	0x6f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74,
+	0x6f, 0x22, 0xce, 0x01, 0x0a, 0x0d, 0x56, 0x6f, 0x74, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x12, 0x30, 0x0a, 0x08, 0x6b, 0x65, 0x79, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x2e, 0x4b, 0x65, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x07, 0x6b, 0x65,
+	0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f,
+	0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x22, 0x7a, 0x0a, 0x12, 0x52, 0x65, 0x74, 0x72, 0x61, 0x63, 0x74, 0x56, 0x6f, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x76, 0x6f, 0x74, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x6f, 0x74, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x4b,
+	0x0a, 0x13, 0x52, 0x65, 0x74, 0x72, 0x61, 0x63, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x2e, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x63, 0x79, 0x52, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x22, 0xb4, 0x01, 0x0a, 0x11,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x19, 0x0a, 0x08, 0x76, 0x6f, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x6f, 0x74, 0x65, 0x72, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x09,
+	0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1b, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x56,
+	0x6f, 0x74, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x64, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x55, 0x70, 0x76,
+	0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x53, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x22, 0x4a, 0x0a, 0x12, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x56, 0x6f, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x06, 0x63, 0x72, 0x79, 0x70,
+	0x74, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74,
+	0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x22, 0xcd,
+	0x01, 0x0a, 0x0b, 0x56, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1d,
+	0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x19, 0x0a,
+	0x08, 0x76, 0x6f, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x76, 0x6f, 0x74, 0x65, 0x72, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x55, 0x70,
+	0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x44,
+	0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x8c,
+	0x01, 0x0a, 0x07, 0x56, 0x6f, 0x74, 0x65, 0x41, 0x63, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x34, 0x0a, 0x06, 0x63, 0x72, 0x79,
+	0x70, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x55, 0x70, 0x76, 0x6f,
+	0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x12,
+	0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x24, 0x0a,
+	0x12, 0x47, 0x65, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x53, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x22, 0x2b, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x53,
+	0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x6f,
+	0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73,
+	0x22, 0x8e, 0x01, 0x0a, 0x0c, 0x56, 0x6f, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x75,
+	0x70, 0x76, 0x6f, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x70, 0x76,
+	0x6f, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x6f, 0x77, 0x6e, 0x76, 0x6f, 0x74, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x64, 0x6f, 0x77, 0x6e, 0x76, 0x6f, 0x74, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x73, 0x75, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x73, 0x75,
+	0x6d, 0x22, 0xc4, 0x01, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2e, 0x0a, 0x04, 0x66,
+	0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x2a, 0x0a, 0x02, 0x74,
+	0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x3f, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x6f, 0x6c,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x55, 0x70,
+	0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x72, 0x65,
+	0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x52, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x56,
+	0x6f, 0x74, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x52, 0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x22, 0xc8, 0x01, 0x0a,
+	0x17, 0x47, 0x65, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x53, 0x75, 0x6d, 0x53, 0x74, 0x72, 0x65, 0x61,
 	0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x2b, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x56,
-	0x6f, 0x74, 0x65, 0x73, 0x53, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
-	0x76, 0x6f, 0x74, 0x65, 0x73, 0x22, 0x29, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x56, 0x6f, 0x74, 0x65,
-	0x53, 0x75, 0x6d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
-	0x22, 0x30, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x53, 0x75, 0x6d, 0x53, 0x74,
-	0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05,
-	0x76, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x6f, 0x74,
-	0x65, 0x73, 0x32, 0xb9, 0x06, 0x0a, 0x0c, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73,
-	0x74, 0x65, 0x6d, 0x12, 0x55, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x72, 0x79,
-	0x70, 0x74, 0x6f, 0x12, 0x21, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74,
-	0x65, 0x6d, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53,
-	0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70,
-	0x74, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x0c, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x12, 0x21, 0x2e, 0x55, 0x70, 0x76,
-	0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e,
-	0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x5b, 0x0a, 0x0e, 0x52, 0x65, 0x61, 0x64, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x42,
-	0x79, 0x49, 0x44, 0x12, 0x23, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74,
-	0x65, 0x6d, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x42, 0x79, 0x49,
-	0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74,
-	0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x43, 0x72, 0x79, 0x70,
-	0x74, 0x6f, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a,
-	0x0a, 0x0d, 0x52, 0x65, 0x61, 0x64, 0x41, 0x6c, 0x6c, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x12,
-	0x22, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x52,
-	0x65, 0x61, 0x64, 0x41, 0x6c, 0x6c, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74,
-	0x65, 0x6d, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x41, 0x6c, 0x6c, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x55, 0x0a, 0x0c, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x12, 0x21, 0x2e, 0x55, 0x70, 0x76,
-	0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e,
-	0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x55, 0x0a, 0x0c, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x48, 0x0a, 0x12, 0x68, 0x65, 0x61, 0x72,
+	0x74, 0x62, 0x65, 0x61, 0x74, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x11, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x12, 0x30, 0x0a, 0x14, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x66, 0x72, 0x6f,
+	0x6d, 0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x12, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x65,
+	0x70, 0x6f, 0x63, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x75,
+	0x6d, 0x65, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x22, 0xd6, 0x01, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x56,
+	0x6f, 0x74, 0x65, 0x53, 0x75, 0x6d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x12, 0x1c, 0x0a, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70,
+	0x6f, 0x63, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68,
+	0x22, 0x27, 0x0a, 0x13, 0x57, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0xd5, 0x01, 0x0a, 0x14, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x31, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1d, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e,
+	0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x34, 0x0a, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x2e, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x63, 0x79, 0x52, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x22, 0x66, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f,
+	0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x06, 0x6d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x55, 0x70, 0x76,
+	0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x62, 0x6f, 0x61, 0x72, 0x64, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x52, 0x06, 0x6d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x72, 0x0a, 0x10, 0x4c, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x12, 0x0a,
+	0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x72, 0x61, 0x6e,
+	0x6b, 0x12, 0x34, 0x0a, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x2e, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x52,
+	0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x52, 0x0a,
+	0x16, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74,
+	0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f,
+	0x61, 0x72, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x22, 0x55, 0x0a, 0x0a, 0x52, 0x61, 0x6e, 0x6b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x23, 0x0a, 0x0d, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x72, 0x61, 0x6e, 0x6b,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73,
+	0x52, 0x61, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x22, 0xc2, 0x01, 0x0a, 0x18, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72,
+	0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12,
+	0x32, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e,
+	0x52, 0x61, 0x6e, 0x6b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x41, 0x0a,
+	0x13, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x22, 0x62, 0x0a, 0x0c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x12, 0x34, 0x0a, 0x06, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e,
+	0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x06,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x6c, 0x65, 0x76, 0x61,
+	0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x72, 0x65, 0x6c, 0x65, 0x76,
+	0x61, 0x6e, 0x63, 0x65, 0x22, 0x4c, 0x0a, 0x14, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x43, 0x72,
+	0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x07,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x2a, 0xa5, 0x01, 0x0a, 0x0b, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x12, 0x11, 0x0a, 0x0d, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x43, 0x52, 0x45, 0x41,
+	0x54, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x4e,
+	0x41, 0x4d, 0x45, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x55,
+	0x50, 0x56, 0x4f, 0x54, 0x45, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x4f, 0x52, 0x44, 0x45, 0x52,
+	0x5f, 0x44, 0x4f, 0x57, 0x4e, 0x56, 0x4f, 0x54, 0x45, 0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f, 0x4f,
+	0x52, 0x44, 0x45, 0x52, 0x5f, 0x4e, 0x45, 0x54, 0x5f, 0x56, 0x4f, 0x54, 0x45, 0x53, 0x10, 0x04,
+	0x12, 0x10, 0x0a, 0x0c, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x57, 0x49, 0x4c, 0x53, 0x4f, 0x4e,
+	0x10, 0x05, 0x12, 0x0d, 0x0a, 0x09, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x48, 0x4f, 0x54, 0x10,
+	0x06, 0x12, 0x17, 0x0a, 0x13, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x52,
+	0x4f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x41, 0x4c, 0x10, 0x07, 0x2a, 0x3a, 0x0a, 0x0d, 0x56, 0x6f,
+	0x74, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0d, 0x0a, 0x09, 0x56,
+	0x4f, 0x54, 0x45, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x56, 0x4f,
+	0x54, 0x45, 0x5f, 0x55, 0x50, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x56, 0x4f, 0x54, 0x45, 0x5f,
+	0x44, 0x4f, 0x57, 0x4e, 0x10, 0x02, 0x2a, 0x42, 0x0a, 0x07, 0x4b, 0x65, 0x79, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x13, 0x0a, 0x0f, 0x4b, 0x45, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x4b, 0x45, 0x59, 0x5f, 0x45, 0x44,
+	0x32, 0x35, 0x35, 0x31, 0x39, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x4b, 0x45, 0x59, 0x5f, 0x53,
+	0x45, 0x43, 0x50, 0x32, 0x35, 0x36, 0x4b, 0x31, 0x10, 0x02, 0x2a, 0x54, 0x0a, 0x11, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x12, 0x0a, 0x0e, 0x52, 0x45, 0x53, 0x4f, 0x4c, 0x55, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x41,
+	0x57, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x52, 0x45, 0x53, 0x4f, 0x4c, 0x55, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x48, 0x4f, 0x55, 0x52, 0x4c, 0x59, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x45,
+	0x53, 0x4f, 0x4c, 0x55, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x41, 0x49, 0x4c, 0x59, 0x10, 0x02,
+	0x2a, 0x6b, 0x0a, 0x0f, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x4e, 0x4f, 0x4e,
+	0x45, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x43, 0x52, 0x45,
+	0x41, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f,
+	0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d, 0x45, 0x56, 0x45,
+	0x4e, 0x54, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b,
+	0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x56, 0x4f, 0x54, 0x45, 0x44, 0x10, 0x04, 0x2a, 0x90, 0x01,
+	0x0a, 0x11, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x4d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x12, 0x14, 0x0a, 0x10, 0x4d, 0x45, 0x54, 0x52, 0x49, 0x43, 0x5f, 0x4e, 0x45,
+	0x54, 0x5f, 0x56, 0x4f, 0x54, 0x45, 0x53, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x4d, 0x45, 0x54,
+	0x52, 0x49, 0x43, 0x5f, 0x55, 0x50, 0x56, 0x4f, 0x54, 0x45, 0x53, 0x10, 0x01, 0x12, 0x14, 0x0a,
+	0x10, 0x4d, 0x45, 0x54, 0x52, 0x49, 0x43, 0x5f, 0x44, 0x4f, 0x57, 0x4e, 0x56, 0x4f, 0x54, 0x45,
+	0x53, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d, 0x4d, 0x45, 0x54, 0x52, 0x49, 0x43, 0x5f, 0x57, 0x49,
+	0x4c, 0x53, 0x4f, 0x4e, 0x10, 0x03, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x54, 0x52, 0x49, 0x43,
+	0x5f, 0x48, 0x4f, 0x54, 0x10, 0x04, 0x12, 0x18, 0x0a, 0x14, 0x4d, 0x45, 0x54, 0x52, 0x49, 0x43,
+	0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x41, 0x4c, 0x10, 0x05,
+	0x32, 0xd8, 0x0c, 0x0a, 0x0c, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x12, 0x55, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74,
+	0x6f, 0x12, 0x21, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x12, 0x21, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74,
+	0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x72,
+	0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x55, 0x70,
+	0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x5b, 0x0a, 0x0e, 0x52, 0x65, 0x61, 0x64, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x42, 0x79, 0x49,
+	0x44, 0x12, 0x23, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x2e, 0x52, 0x65, 0x61, 0x64, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x42, 0x79, 0x49, 0x44, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f,
+	0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x67, 0x0a, 0x12,
+	0x52, 0x65, 0x61, 0x64, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x42, 0x79, 0x53, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x12, 0x27, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x42, 0x79, 0x53, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x55, 0x70,
+	0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x43,
+	0x72, 0x79, 0x70, 0x74, 0x6f, 0x42, 0x79, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x0d, 0x52, 0x65, 0x61, 0x64, 0x41, 0x6c, 0x6c,
+	0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x12, 0x22, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x41, 0x6c, 0x6c, 0x43, 0x72, 0x79,
+	0x70, 0x74, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x55, 0x70, 0x76,
+	0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x41, 0x6c,
+	0x6c, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30,
+	0x01, 0x12, 0x55, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74,
 	0x6f, 0x12, 0x21, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
-	0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x71,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73,
-	0x74, 0x65, 0x6d, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5b, 0x0a, 0x0e, 0x44, 0x6f, 0x77, 0x6e,
-	0x76, 0x6f, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x12, 0x23, 0x2e, 0x55, 0x70, 0x76,
-	0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x76, 0x6f,
-	0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x24, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x44,
-	0x6f, 0x77, 0x6e, 0x76, 0x6f, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x56, 0x6f, 0x74, 0x65,
+	0x74, 0x65, 0x6d, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x0c, 0x55, 0x70, 0x76, 0x6f,
+	0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x12, 0x21, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74,
+	0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x43, 0x72,
+	0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x55, 0x70,
+	0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74,
+	0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x5b, 0x0a, 0x0e, 0x44, 0x6f, 0x77, 0x6e, 0x76, 0x6f, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74,
+	0x6f, 0x12, 0x23, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x76, 0x6f, 0x74, 0x65, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x76, 0x6f, 0x74, 0x65, 0x43, 0x72,
+	0x79, 0x70, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x0b,
+	0x52, 0x65, 0x74, 0x72, 0x61, 0x63, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x12, 0x20, 0x2e, 0x55, 0x70,
+	0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x61,
+	0x63, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e,
+	0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x52, 0x65, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x4f, 0x0a, 0x0a, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x56, 0x6f, 0x74, 0x65, 0x12, 0x1f,
+	0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x20, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x42, 0x0a, 0x0a, 0x56, 0x6f, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12,
+	0x19, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x56,
+	0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x1a, 0x15, 0x2e, 0x55, 0x70, 0x76,
+	0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x41, 0x63,
+	0x6b, 0x28, 0x01, 0x30, 0x01, 0x12, 0x52, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x56, 0x6f, 0x74, 0x65,
 	0x73, 0x53, 0x75, 0x6d, 0x12, 0x20, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73,
 	0x74, 0x65, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x53, 0x75, 0x6d, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53,
@@ -1066,9 +3247,38 @@ var file_proto_UpvoteSystem_proto_rawDesc = []byte{
 	0x56, 0x6f, 0x74, 0x65, 0x53, 0x75, 0x6d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73,
 	0x74, 0x65, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x53, 0x75, 0x6d, 0x53, 0x74,
-	0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0x14,
-	0x5a, 0x12, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79,
-	0x73, 0x74, 0x65, 0x6d, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x5b,
+	0x0a, 0x0e, 0x47, 0x65, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x12, 0x23, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e,
+	0x47, 0x65, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0c, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x73, 0x12, 0x21, 0x2e, 0x55, 0x70,
+	0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22,
+	0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x30, 0x01, 0x12, 0x5b, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x23, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62,
+	0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x55, 0x70,
+	0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x61, 0x0a, 0x10, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x23, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f,
+	0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x55, 0x70, 0x76,
+	0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4c,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x30, 0x01, 0x12, 0x55, 0x0a, 0x0c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x43, 0x72,
+	0x79, 0x70, 0x74, 0x6f, 0x12, 0x21, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65,
+	0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x43, 0x72, 0x79,
+	0x70, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x14, 0x5a, 0x12, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x55, 0x70, 0x76, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1083,60 +3293,142 @@ func file_proto_UpvoteSystem_proto_rawDescGZIP() []byte {
 	return file_proto_UpvoteSystem_proto_rawDescData
 }
 
-var file_proto_UpvoteSystem_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_proto_UpvoteSystem_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_proto_UpvoteSystem_proto_msgTypes = make([]protoimpl.MessageInfo, 41)
 var file_proto_UpvoteSystem_proto_goTypes = []interface{}{
-	(*Cryptocurrency)(nil),           // 0: UpvoteSystem.Cryptocurrency
-	(*CreateCryptoRequest)(nil),      // 1: UpvoteSystem.CreateCryptoRequest
-	(*CreateCryptoResponse)(nil),     // 2: UpvoteSystem.CreateCryptoResponse
-	(*DeleteCryptoRequest)(nil),      // 3: UpvoteSystem.DeleteCryptoRequest
-	(*DeleteCryptoResponse)(nil),     // 4: UpvoteSystem.DeleteCryptoResponse
-	(*ReadCryptoByIDRequest)(nil),    // 5: UpvoteSystem.ReadCryptoByIDRequest
-	(*ReadCryptoByIDResponse)(nil),   // 6: UpvoteSystem.ReadCryptoByIDResponse
-	(*ReadAllCryptoRequest)(nil),     // 7: UpvoteSystem.ReadAllCryptoRequest
-	(*ReadAllCryptoResponse)(nil),    // 8: UpvoteSystem.ReadAllCryptoResponse
-	(*UpdateCryptoRequest)(nil),      // 9: UpvoteSystem.UpdateCryptoRequest
-	(*UpdateCryptoResponse)(nil),     // 10: UpvoteSystem.UpdateCryptoResponse
-	(*UpvoteCryptoRequest)(nil),      // 11: UpvoteSystem.UpvoteCryptoRequest
-	(*UpvoteCryptoResponse)(nil),     // 12: UpvoteSystem.UpvoteCryptoResponse
-	(*DownvoteCryptoRequest)(nil),    // 13: UpvoteSystem.DownvoteCryptoRequest
-	(*DownvoteCryptoResponse)(nil),   // 14: UpvoteSystem.DownvoteCryptoResponse
-	(*GetVotesSumRequest)(nil),       // 15: UpvoteSystem.GetVotesSumRequest
-	(*GetVotesSumResponse)(nil),      // 16: UpvoteSystem.GetVotesSumResponse
-	(*GetVoteSumStreamRequest)(nil),  // 17: UpvoteSystem.GetVoteSumStreamRequest
-	(*GetVoteSumStreamResponse)(nil), // 18: UpvoteSystem.GetVoteSumStreamResponse
+	(CryptoOrder)(0),                   // 0: UpvoteSystem.CryptoOrder
+	(VoteDirection)(0),                 // 1: UpvoteSystem.VoteDirection
+	(KeyType)(0),                       // 2: UpvoteSystem.KeyType
+	(HistoryResolution)(0),             // 3: UpvoteSystem.HistoryResolution
+	(CryptoEventType)(0),               // 4: UpvoteSystem.CryptoEventType
+	(LeaderboardMetric)(0),             // 5: UpvoteSystem.LeaderboardMetric
+	(*Cryptocurrency)(nil),             // 6: UpvoteSystem.Cryptocurrency
+	(*CreateCryptoRequest)(nil),        // 7: UpvoteSystem.CreateCryptoRequest
+	(*CreateCryptoResponse)(nil),       // 8: UpvoteSystem.CreateCryptoResponse
+	(*DeleteCryptoRequest)(nil),        // 9: UpvoteSystem.DeleteCryptoRequest
+	(*DeleteCryptoResponse)(nil),       // 10: UpvoteSystem.DeleteCryptoResponse
+	(*ReadCryptoByIDRequest)(nil),      // 11: UpvoteSystem.ReadCryptoByIDRequest
+	(*ReadCryptoByIDResponse)(nil),     // 12: UpvoteSystem.ReadCryptoByIDResponse
+	(*ReadCryptoBySymbolRequest)(nil),  // 13: UpvoteSystem.ReadCryptoBySymbolRequest
+	(*ReadCryptoBySymbolResponse)(nil), // 14: UpvoteSystem.ReadCryptoBySymbolResponse
+	(*ReadAllCryptoRequest)(nil),       // 15: UpvoteSystem.ReadAllCryptoRequest
+	(*ReadAllCryptoResponse)(nil),      // 16: UpvoteSystem.ReadAllCryptoResponse
+	(*UpdateCryptoRequest)(nil),        // 17: UpvoteSystem.UpdateCryptoRequest
+	(*UpdateCryptoResponse)(nil),       // 18: UpvoteSystem.UpdateCryptoResponse
+	(*UpvoteCryptoRequest)(nil),        // 19: UpvoteSystem.UpvoteCryptoRequest
+	(*UpvoteCryptoResponse)(nil),       // 20: UpvoteSystem.UpvoteCryptoResponse
+	(*DownvoteCryptoRequest)(nil),      // 21: UpvoteSystem.DownvoteCryptoRequest
+	(*DownvoteCryptoResponse)(nil),     // 22: UpvoteSystem.DownvoteCryptoResponse
+	(*VoteSignature)(nil),              // 23: UpvoteSystem.VoteSignature
+	(*RetractVoteRequest)(nil),         // 24: UpvoteSystem.RetractVoteRequest
+	(*RetractVoteResponse)(nil),        // 25: UpvoteSystem.RetractVoteResponse
+	(*ChangeVoteRequest)(nil),          // 26: UpvoteSystem.ChangeVoteRequest
+	(*ChangeVoteResponse)(nil),         // 27: UpvoteSystem.ChangeVoteResponse
+	(*VoteCommand)(nil),                // 28: UpvoteSystem.VoteCommand
+	(*VoteAck)(nil),                    // 29: UpvoteSystem.VoteAck
+	(*GetVotesSumRequest)(nil),         // 30: UpvoteSystem.GetVotesSumRequest
+	(*GetVotesSumResponse)(nil),        // 31: UpvoteSystem.GetVotesSumResponse
+	(*VoteSnapshot)(nil),               // 32: UpvoteSystem.VoteSnapshot
+	(*GetVoteHistoryRequest)(nil),      // 33: UpvoteSystem.GetVoteHistoryRequest
+	(*GetVoteHistoryResponse)(nil),     // 34: UpvoteSystem.GetVoteHistoryResponse
+	(*GetVoteSumStreamRequest)(nil),    // 35: UpvoteSystem.GetVoteSumStreamRequest
+	(*GetVoteSumStreamResponse)(nil),   // 36: UpvoteSystem.GetVoteSumStreamResponse
+	(*WatchCryptosRequest)(nil),        // 37: UpvoteSystem.WatchCryptosRequest
+	(*WatchCryptosResponse)(nil),       // 38: UpvoteSystem.WatchCryptosResponse
+	(*GetLeaderboardRequest)(nil),      // 39: UpvoteSystem.GetLeaderboardRequest
+	(*LeaderboardEntry)(nil),           // 40: UpvoteSystem.LeaderboardEntry
+	(*GetLeaderboardResponse)(nil),     // 41: UpvoteSystem.GetLeaderboardResponse
+	(*RankChange)(nil),                 // 42: UpvoteSystem.RankChange
+	(*WatchLeaderboardResponse)(nil),   // 43: UpvoteSystem.WatchLeaderboardResponse
+	(*SearchCryptoRequest)(nil),        // 44: UpvoteSystem.SearchCryptoRequest
+	(*SearchResult)(nil),               // 45: UpvoteSystem.SearchResult
+	(*SearchCryptoResponse)(nil),       // 46: UpvoteSystem.SearchCryptoResponse
+	(*timestamppb.Timestamp)(nil),      // 47: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),        // 48: google.protobuf.Duration
 }
 var file_proto_UpvoteSystem_proto_depIdxs = []int32{
-	0,  // 0: UpvoteSystem.CreateCryptoRequest.crypto:type_name -> UpvoteSystem.Cryptocurrency
-	0,  // 1: UpvoteSystem.CreateCryptoResponse.crypto:type_name -> UpvoteSystem.Cryptocurrency
-	0,  // 2: UpvoteSystem.ReadCryptoByIDResponse.crypto:type_name -> UpvoteSystem.Cryptocurrency
-	0,  // 3: UpvoteSystem.ReadAllCryptoResponse.crypto:type_name -> UpvoteSystem.Cryptocurrency
-	0,  // 4: UpvoteSystem.UpdateCryptoRequest.crypto:type_name -> UpvoteSystem.Cryptocurrency
-	0,  // 5: UpvoteSystem.UpdateCryptoResponse.crypto:type_name -> UpvoteSystem.Cryptocurrency
-	0,  // 6: UpvoteSystem.UpvoteCryptoResponse.crypto:type_name -> UpvoteSystem.Cryptocurrency
-	0,  // 7: UpvoteSystem.DownvoteCryptoResponse.crypto:type_name -> UpvoteSystem.Cryptocurrency
-	1,  // 8: UpvoteSystem.UpvoteSystem.CreateCrypto:input_type -> UpvoteSystem.CreateCryptoRequest
-	3,  // 9: UpvoteSystem.UpvoteSystem.DeleteCrypto:input_type -> UpvoteSystem.DeleteCryptoRequest
-	5,  // 10: UpvoteSystem.UpvoteSystem.ReadCryptoByID:input_type -> UpvoteSystem.ReadCryptoByIDRequest
-	7,  // 11: UpvoteSystem.UpvoteSystem.ReadAllCrypto:input_type -> UpvoteSystem.ReadAllCryptoRequest
-	9,  // 12: UpvoteSystem.UpvoteSystem.UpdateCrypto:input_type -> UpvoteSystem.UpdateCryptoRequest
-	11, // 13: UpvoteSystem.UpvoteSystem.UpvoteCrypto:input_type -> UpvoteSystem.UpvoteCryptoRequest
-	13, // 14: UpvoteSystem.UpvoteSystem.DownvoteCrypto:input_type -> UpvoteSystem.DownvoteCryptoRequest
-	15, // 15: UpvoteSystem.UpvoteSystem.GetVotesSum:input_type -> UpvoteSystem.GetVotesSumRequest
-	17, // 16: UpvoteSystem.UpvoteSystem.GetVoteSumStream:input_type -> UpvoteSystem.GetVoteSumStreamRequest
-	2,  // 17: UpvoteSystem.UpvoteSystem.CreateCrypto:output_type -> UpvoteSystem.CreateCryptoResponse
-	4,  // 18: UpvoteSystem.UpvoteSystem.DeleteCrypto:output_type -> UpvoteSystem.DeleteCryptoResponse
-	6,  // 19: UpvoteSystem.UpvoteSystem.ReadCryptoByID:output_type -> UpvoteSystem.ReadCryptoByIDResponse
-	8,  // 20: UpvoteSystem.UpvoteSystem.ReadAllCrypto:output_type -> UpvoteSystem.ReadAllCryptoResponse
-	10, // 21: UpvoteSystem.UpvoteSystem.UpdateCrypto:output_type -> UpvoteSystem.UpdateCryptoResponse
-	12, // 22: UpvoteSystem.UpvoteSystem.UpvoteCrypto:output_type -> UpvoteSystem.UpvoteCryptoResponse
-	14, // 23: UpvoteSystem.UpvoteSystem.DownvoteCrypto:output_type -> UpvoteSystem.DownvoteCryptoResponse
-	16, // 24: UpvoteSystem.UpvoteSystem.GetVotesSum:output_type -> UpvoteSystem.GetVotesSumResponse
-	18, // 25: UpvoteSystem.UpvoteSystem.GetVoteSumStream:output_type -> UpvoteSystem.GetVoteSumStreamResponse
-	17, // [17:26] is the sub-list for method output_type
-	8,  // [8:17] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	47, // 0: UpvoteSystem.Cryptocurrency.launch_date:type_name -> google.protobuf.Timestamp
+	6,  // 1: UpvoteSystem.CreateCryptoRequest.crypto:type_name -> UpvoteSystem.Cryptocurrency
+	6,  // 2: UpvoteSystem.CreateCryptoResponse.crypto:type_name -> UpvoteSystem.Cryptocurrency
+	6,  // 3: UpvoteSystem.ReadCryptoByIDResponse.crypto:type_name -> UpvoteSystem.Cryptocurrency
+	6,  // 4: UpvoteSystem.ReadCryptoBySymbolResponse.crypto:type_name -> UpvoteSystem.Cryptocurrency
+	0,  // 5: UpvoteSystem.ReadAllCryptoRequest.order_by:type_name -> UpvoteSystem.CryptoOrder
+	6,  // 6: UpvoteSystem.ReadAllCryptoResponse.crypto:type_name -> UpvoteSystem.Cryptocurrency
+	6,  // 7: UpvoteSystem.UpdateCryptoRequest.crypto:type_name -> UpvoteSystem.Cryptocurrency
+	6,  // 8: UpvoteSystem.UpdateCryptoResponse.crypto:type_name -> UpvoteSystem.Cryptocurrency
+	23, // 9: UpvoteSystem.UpvoteCryptoRequest.signature:type_name -> UpvoteSystem.VoteSignature
+	6,  // 10: UpvoteSystem.UpvoteCryptoResponse.crypto:type_name -> UpvoteSystem.Cryptocurrency
+	23, // 11: UpvoteSystem.DownvoteCryptoRequest.signature:type_name -> UpvoteSystem.VoteSignature
+	6,  // 12: UpvoteSystem.DownvoteCryptoResponse.crypto:type_name -> UpvoteSystem.Cryptocurrency
+	2,  // 13: UpvoteSystem.VoteSignature.key_type:type_name -> UpvoteSystem.KeyType
+	47, // 14: UpvoteSystem.VoteSignature.timestamp:type_name -> google.protobuf.Timestamp
+	23, // 15: UpvoteSystem.RetractVoteRequest.signature:type_name -> UpvoteSystem.VoteSignature
+	6,  // 16: UpvoteSystem.RetractVoteResponse.crypto:type_name -> UpvoteSystem.Cryptocurrency
+	1,  // 17: UpvoteSystem.ChangeVoteRequest.direction:type_name -> UpvoteSystem.VoteDirection
+	23, // 18: UpvoteSystem.ChangeVoteRequest.signature:type_name -> UpvoteSystem.VoteSignature
+	6,  // 19: UpvoteSystem.ChangeVoteResponse.crypto:type_name -> UpvoteSystem.Cryptocurrency
+	1,  // 20: UpvoteSystem.VoteCommand.direction:type_name -> UpvoteSystem.VoteDirection
+	23, // 21: UpvoteSystem.VoteCommand.signature:type_name -> UpvoteSystem.VoteSignature
+	6,  // 22: UpvoteSystem.VoteAck.crypto:type_name -> UpvoteSystem.Cryptocurrency
+	47, // 23: UpvoteSystem.VoteSnapshot.timestamp:type_name -> google.protobuf.Timestamp
+	47, // 24: UpvoteSystem.GetVoteHistoryRequest.from:type_name -> google.protobuf.Timestamp
+	47, // 25: UpvoteSystem.GetVoteHistoryRequest.to:type_name -> google.protobuf.Timestamp
+	3,  // 26: UpvoteSystem.GetVoteHistoryRequest.resolution:type_name -> UpvoteSystem.HistoryResolution
+	32, // 27: UpvoteSystem.GetVoteHistoryResponse.snapshots:type_name -> UpvoteSystem.VoteSnapshot
+	48, // 28: UpvoteSystem.GetVoteSumStreamRequest.heartbeat_interval:type_name -> google.protobuf.Duration
+	47, // 29: UpvoteSystem.GetVoteSumStreamResponse.timestamp:type_name -> google.protobuf.Timestamp
+	4,  // 30: UpvoteSystem.WatchCryptosResponse.type:type_name -> UpvoteSystem.CryptoEventType
+	6,  // 31: UpvoteSystem.WatchCryptosResponse.crypto:type_name -> UpvoteSystem.Cryptocurrency
+	47, // 32: UpvoteSystem.WatchCryptosResponse.timestamp:type_name -> google.protobuf.Timestamp
+	5,  // 33: UpvoteSystem.GetLeaderboardRequest.metric:type_name -> UpvoteSystem.LeaderboardMetric
+	6,  // 34: UpvoteSystem.LeaderboardEntry.crypto:type_name -> UpvoteSystem.Cryptocurrency
+	40, // 35: UpvoteSystem.GetLeaderboardResponse.entries:type_name -> UpvoteSystem.LeaderboardEntry
+	40, // 36: UpvoteSystem.WatchLeaderboardResponse.entries:type_name -> UpvoteSystem.LeaderboardEntry
+	42, // 37: UpvoteSystem.WatchLeaderboardResponse.changes:type_name -> UpvoteSystem.RankChange
+	47, // 38: UpvoteSystem.WatchLeaderboardResponse.timestamp:type_name -> google.protobuf.Timestamp
+	6,  // 39: UpvoteSystem.SearchResult.crypto:type_name -> UpvoteSystem.Cryptocurrency
+	45, // 40: UpvoteSystem.SearchCryptoResponse.results:type_name -> UpvoteSystem.SearchResult
+	7,  // 41: UpvoteSystem.UpvoteSystem.CreateCrypto:input_type -> UpvoteSystem.CreateCryptoRequest
+	9,  // 42: UpvoteSystem.UpvoteSystem.DeleteCrypto:input_type -> UpvoteSystem.DeleteCryptoRequest
+	11, // 43: UpvoteSystem.UpvoteSystem.ReadCryptoByID:input_type -> UpvoteSystem.ReadCryptoByIDRequest
+	13, // 44: UpvoteSystem.UpvoteSystem.ReadCryptoBySymbol:input_type -> UpvoteSystem.ReadCryptoBySymbolRequest
+	15, // 45: UpvoteSystem.UpvoteSystem.ReadAllCrypto:input_type -> UpvoteSystem.ReadAllCryptoRequest
+	17, // 46: UpvoteSystem.UpvoteSystem.UpdateCrypto:input_type -> UpvoteSystem.UpdateCryptoRequest
+	19, // 47: UpvoteSystem.UpvoteSystem.UpvoteCrypto:input_type -> UpvoteSystem.UpvoteCryptoRequest
+	21, // 48: UpvoteSystem.UpvoteSystem.DownvoteCrypto:input_type -> UpvoteSystem.DownvoteCryptoRequest
+	24, // 49: UpvoteSystem.UpvoteSystem.RetractVote:input_type -> UpvoteSystem.RetractVoteRequest
+	26, // 50: UpvoteSystem.UpvoteSystem.ChangeVote:input_type -> UpvoteSystem.ChangeVoteRequest
+	28, // 51: UpvoteSystem.UpvoteSystem.VoteStream:input_type -> UpvoteSystem.VoteCommand
+	30, // 52: UpvoteSystem.UpvoteSystem.GetVotesSum:input_type -> UpvoteSystem.GetVotesSumRequest
+	35, // 53: UpvoteSystem.UpvoteSystem.GetVoteSumStream:input_type -> UpvoteSystem.GetVoteSumStreamRequest
+	33, // 54: UpvoteSystem.UpvoteSystem.GetVoteHistory:input_type -> UpvoteSystem.GetVoteHistoryRequest
+	37, // 55: UpvoteSystem.UpvoteSystem.WatchCryptos:input_type -> UpvoteSystem.WatchCryptosRequest
+	39, // 56: UpvoteSystem.UpvoteSystem.GetLeaderboard:input_type -> UpvoteSystem.GetLeaderboardRequest
+	39, // 57: UpvoteSystem.UpvoteSystem.WatchLeaderboard:input_type -> UpvoteSystem.GetLeaderboardRequest
+	44, // 58: UpvoteSystem.UpvoteSystem.SearchCrypto:input_type -> UpvoteSystem.SearchCryptoRequest
+	8,  // 59: UpvoteSystem.UpvoteSystem.CreateCrypto:output_type -> UpvoteSystem.CreateCryptoResponse
+	10, // 60: UpvoteSystem.UpvoteSystem.DeleteCrypto:output_type -> UpvoteSystem.DeleteCryptoResponse
+	12, // 61: UpvoteSystem.UpvoteSystem.ReadCryptoByID:output_type -> UpvoteSystem.ReadCryptoByIDResponse
+	14, // 62: UpvoteSystem.UpvoteSystem.ReadCryptoBySymbol:output_type -> UpvoteSystem.ReadCryptoBySymbolResponse
+	16, // 63: UpvoteSystem.UpvoteSystem.ReadAllCrypto:output_type -> UpvoteSystem.ReadAllCryptoResponse
+	18, // 64: UpvoteSystem.UpvoteSystem.UpdateCrypto:output_type -> UpvoteSystem.UpdateCryptoResponse
+	20, // 65: UpvoteSystem.UpvoteSystem.UpvoteCrypto:output_type -> UpvoteSystem.UpvoteCryptoResponse
+	22, // 66: UpvoteSystem.UpvoteSystem.DownvoteCrypto:output_type -> UpvoteSystem.DownvoteCryptoResponse
+	25, // 67: UpvoteSystem.UpvoteSystem.RetractVote:output_type -> UpvoteSystem.RetractVoteResponse
+	27, // 68: UpvoteSystem.UpvoteSystem.ChangeVote:output_type -> UpvoteSystem.ChangeVoteResponse
+	29, // 69: UpvoteSystem.UpvoteSystem.VoteStream:output_type -> UpvoteSystem.VoteAck
+	31, // 70: UpvoteSystem.UpvoteSystem.GetVotesSum:output_type -> UpvoteSystem.GetVotesSumResponse
+	36, // 71: UpvoteSystem.UpvoteSystem.GetVoteSumStream:output_type -> UpvoteSystem.GetVoteSumStreamResponse
+	34, // 72: UpvoteSystem.UpvoteSystem.GetVoteHistory:output_type -> UpvoteSystem.GetVoteHistoryResponse
+	38, // 73: UpvoteSystem.UpvoteSystem.WatchCryptos:output_type -> UpvoteSystem.WatchCryptosResponse
+	41, // 74: UpvoteSystem.UpvoteSystem.GetLeaderboard:output_type -> UpvoteSystem.GetLeaderboardResponse
+	43, // 75: UpvoteSystem.UpvoteSystem.WatchLeaderboard:output_type -> UpvoteSystem.WatchLeaderboardResponse
+	46, // 76: UpvoteSystem.UpvoteSystem.SearchCrypto:output_type -> UpvoteSystem.SearchCryptoResponse
+	59, // [59:77] is the sub-list for method output_type
+	41, // [41:59] is the sub-list for method input_type
+	41, // [41:41] is the sub-list for extension type_name
+	41, // [41:41] is the sub-list for extension extendee
+	0,  // [0:41] is the sub-list for field type_name
 }
 
 func init() { file_proto_UpvoteSystem_proto_init() }
@@ -1230,7 +3522,7 @@ func file_proto_UpvoteSystem_proto_init() {
 			}
 		}
 		file_proto_UpvoteSystem_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReadAllCryptoRequest); i {
+			switch v := v.(*ReadCryptoBySymbolRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1242,7 +3534,7 @@ func file_proto_UpvoteSystem_proto_init() {
 			}
 		}
 		file_proto_UpvoteSystem_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReadAllCryptoResponse); i {
+			switch v := v.(*ReadCryptoBySymbolResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1254,7 +3546,7 @@ func file_proto_UpvoteSystem_proto_init() {
 			}
 		}
 		file_proto_UpvoteSystem_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateCryptoRequest); i {
+			switch v := v.(*ReadAllCryptoRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1266,7 +3558,7 @@ func file_proto_UpvoteSystem_proto_init() {
 			}
 		}
 		file_proto_UpvoteSystem_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateCryptoResponse); i {
+			switch v := v.(*ReadAllCryptoResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1278,7 +3570,7 @@ func file_proto_UpvoteSystem_proto_init() {
 			}
 		}
 		file_proto_UpvoteSystem_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpvoteCryptoRequest); i {
+			switch v := v.(*UpdateCryptoRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1290,7 +3582,7 @@ func file_proto_UpvoteSystem_proto_init() {
 			}
 		}
 		file_proto_UpvoteSystem_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpvoteCryptoResponse); i {
+			switch v := v.(*UpdateCryptoResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1302,7 +3594,7 @@ func file_proto_UpvoteSystem_proto_init() {
 			}
 		}
 		file_proto_UpvoteSystem_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DownvoteCryptoRequest); i {
+			switch v := v.(*UpvoteCryptoRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1314,7 +3606,7 @@ func file_proto_UpvoteSystem_proto_init() {
 			}
 		}
 		file_proto_UpvoteSystem_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DownvoteCryptoResponse); i {
+			switch v := v.(*UpvoteCryptoResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1326,7 +3618,7 @@ func file_proto_UpvoteSystem_proto_init() {
 			}
 		}
 		file_proto_UpvoteSystem_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetVotesSumRequest); i {
+			switch v := v.(*DownvoteCryptoRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1338,7 +3630,7 @@ func file_proto_UpvoteSystem_proto_init() {
 			}
 		}
 		file_proto_UpvoteSystem_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetVotesSumResponse); i {
+			switch v := v.(*DownvoteCryptoResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1350,7 +3642,7 @@ func file_proto_UpvoteSystem_proto_init() {
 			}
 		}
 		file_proto_UpvoteSystem_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetVoteSumStreamRequest); i {
+			switch v := v.(*VoteSignature); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1362,6 +3654,150 @@ func file_proto_UpvoteSystem_proto_init() {
 			}
 		}
 		file_proto_UpvoteSystem_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetractVoteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_UpvoteSystem_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetractVoteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_UpvoteSystem_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangeVoteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_UpvoteSystem_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangeVoteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_UpvoteSystem_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VoteCommand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_UpvoteSystem_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VoteAck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_UpvoteSystem_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetVotesSumRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_UpvoteSystem_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetVotesSumResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_UpvoteSystem_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VoteSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_UpvoteSystem_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetVoteHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_UpvoteSystem_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetVoteHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_UpvoteSystem_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetVoteSumStreamRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_UpvoteSystem_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetVoteSumStreamResponse); i {
 			case 0:
 				return &v.state
@@ -1373,19 +3809,140 @@ func file_proto_UpvoteSystem_proto_init() {
 				return nil
 			}
 		}
+		file_proto_UpvoteSystem_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchCryptosRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_UpvoteSystem_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchCryptosResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_UpvoteSystem_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLeaderboardRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_UpvoteSystem_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LeaderboardEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_UpvoteSystem_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLeaderboardResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_UpvoteSystem_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RankChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_UpvoteSystem_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchLeaderboardResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_UpvoteSystem_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchCryptoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_UpvoteSystem_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_UpvoteSystem_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchCryptoResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_UpvoteSystem_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   19,
+			NumEnums:      6,
+			NumMessages:   41,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_UpvoteSystem_proto_goTypes,
 		DependencyIndexes: file_proto_UpvoteSystem_proto_depIdxs,
+		EnumInfos:         file_proto_UpvoteSystem_proto_enumTypes,
 		MessageInfos:      file_proto_UpvoteSystem_proto_msgTypes,
 	}.Build()
 	File_proto_UpvoteSystem_proto = out.File
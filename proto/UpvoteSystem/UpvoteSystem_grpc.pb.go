@@ -21,12 +21,21 @@ type UpvoteSystemClient interface {
 	CreateCrypto(ctx context.Context, in *CreateCryptoRequest, opts ...grpc.CallOption) (*CreateCryptoResponse, error)
 	DeleteCrypto(ctx context.Context, in *DeleteCryptoRequest, opts ...grpc.CallOption) (*DeleteCryptoResponse, error)
 	ReadCryptoByID(ctx context.Context, in *ReadCryptoByIDRequest, opts ...grpc.CallOption) (*ReadCryptoByIDResponse, error)
+	ReadCryptoBySymbol(ctx context.Context, in *ReadCryptoBySymbolRequest, opts ...grpc.CallOption) (*ReadCryptoBySymbolResponse, error)
 	ReadAllCrypto(ctx context.Context, in *ReadAllCryptoRequest, opts ...grpc.CallOption) (UpvoteSystem_ReadAllCryptoClient, error)
 	UpdateCrypto(ctx context.Context, in *UpdateCryptoRequest, opts ...grpc.CallOption) (*UpdateCryptoResponse, error)
 	UpvoteCrypto(ctx context.Context, in *UpvoteCryptoRequest, opts ...grpc.CallOption) (*UpvoteCryptoResponse, error)
 	DownvoteCrypto(ctx context.Context, in *DownvoteCryptoRequest, opts ...grpc.CallOption) (*DownvoteCryptoResponse, error)
+	RetractVote(ctx context.Context, in *RetractVoteRequest, opts ...grpc.CallOption) (*RetractVoteResponse, error)
+	ChangeVote(ctx context.Context, in *ChangeVoteRequest, opts ...grpc.CallOption) (*ChangeVoteResponse, error)
+	VoteStream(ctx context.Context, opts ...grpc.CallOption) (UpvoteSystem_VoteStreamClient, error)
 	GetVotesSum(ctx context.Context, in *GetVotesSumRequest, opts ...grpc.CallOption) (*GetVotesSumResponse, error)
 	GetVoteSumStream(ctx context.Context, in *GetVoteSumStreamRequest, opts ...grpc.CallOption) (UpvoteSystem_GetVoteSumStreamClient, error)
+	GetVoteHistory(ctx context.Context, in *GetVoteHistoryRequest, opts ...grpc.CallOption) (*GetVoteHistoryResponse, error)
+	WatchCryptos(ctx context.Context, in *WatchCryptosRequest, opts ...grpc.CallOption) (UpvoteSystem_WatchCryptosClient, error)
+	GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*GetLeaderboardResponse, error)
+	WatchLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (UpvoteSystem_WatchLeaderboardClient, error)
+	SearchCrypto(ctx context.Context, in *SearchCryptoRequest, opts ...grpc.CallOption) (*SearchCryptoResponse, error)
 }
 
 type upvoteSystemClient struct {
@@ -64,6 +73,15 @@ func (c *upvoteSystemClient) ReadCryptoByID(ctx context.Context, in *ReadCryptoB
 	return out, nil
 }
 
+func (c *upvoteSystemClient) ReadCryptoBySymbol(ctx context.Context, in *ReadCryptoBySymbolRequest, opts ...grpc.CallOption) (*ReadCryptoBySymbolResponse, error) {
+	out := new(ReadCryptoBySymbolResponse)
+	err := c.cc.Invoke(ctx, "/UpvoteSystem.UpvoteSystem/ReadCryptoBySymbol", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *upvoteSystemClient) ReadAllCrypto(ctx context.Context, in *ReadAllCryptoRequest, opts ...grpc.CallOption) (UpvoteSystem_ReadAllCryptoClient, error) {
 	stream, err := c.cc.NewStream(ctx, &UpvoteSystem_ServiceDesc.Streams[0], "/UpvoteSystem.UpvoteSystem/ReadAllCrypto", opts...)
 	if err != nil {
@@ -123,6 +141,55 @@ func (c *upvoteSystemClient) DownvoteCrypto(ctx context.Context, in *DownvoteCry
 	return out, nil
 }
 
+func (c *upvoteSystemClient) RetractVote(ctx context.Context, in *RetractVoteRequest, opts ...grpc.CallOption) (*RetractVoteResponse, error) {
+	out := new(RetractVoteResponse)
+	err := c.cc.Invoke(ctx, "/UpvoteSystem.UpvoteSystem/RetractVote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *upvoteSystemClient) ChangeVote(ctx context.Context, in *ChangeVoteRequest, opts ...grpc.CallOption) (*ChangeVoteResponse, error) {
+	out := new(ChangeVoteResponse)
+	err := c.cc.Invoke(ctx, "/UpvoteSystem.UpvoteSystem/ChangeVote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *upvoteSystemClient) VoteStream(ctx context.Context, opts ...grpc.CallOption) (UpvoteSystem_VoteStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UpvoteSystem_ServiceDesc.Streams[1], "/UpvoteSystem.UpvoteSystem/VoteStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &upvoteSystemVoteStreamClient{stream}
+	return x, nil
+}
+
+type UpvoteSystem_VoteStreamClient interface {
+	Send(*VoteCommand) error
+	Recv() (*VoteAck, error)
+	grpc.ClientStream
+}
+
+type upvoteSystemVoteStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *upvoteSystemVoteStreamClient) Send(m *VoteCommand) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *upvoteSystemVoteStreamClient) Recv() (*VoteAck, error) {
+	m := new(VoteAck)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *upvoteSystemClient) GetVotesSum(ctx context.Context, in *GetVotesSumRequest, opts ...grpc.CallOption) (*GetVotesSumResponse, error) {
 	out := new(GetVotesSumResponse)
 	err := c.cc.Invoke(ctx, "/UpvoteSystem.UpvoteSystem/GetVotesSum", in, out, opts...)
@@ -133,7 +200,7 @@ func (c *upvoteSystemClient) GetVotesSum(ctx context.Context, in *GetVotesSumReq
 }
 
 func (c *upvoteSystemClient) GetVoteSumStream(ctx context.Context, in *GetVoteSumStreamRequest, opts ...grpc.CallOption) (UpvoteSystem_GetVoteSumStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &UpvoteSystem_ServiceDesc.Streams[1], "/UpvoteSystem.UpvoteSystem/GetVoteSumStream", opts...)
+	stream, err := c.cc.NewStream(ctx, &UpvoteSystem_ServiceDesc.Streams[2], "/UpvoteSystem.UpvoteSystem/GetVoteSumStream", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -164,6 +231,97 @@ func (x *upvoteSystemGetVoteSumStreamClient) Recv() (*GetVoteSumStreamResponse,
 	return m, nil
 }
 
+func (c *upvoteSystemClient) GetVoteHistory(ctx context.Context, in *GetVoteHistoryRequest, opts ...grpc.CallOption) (*GetVoteHistoryResponse, error) {
+	out := new(GetVoteHistoryResponse)
+	err := c.cc.Invoke(ctx, "/UpvoteSystem.UpvoteSystem/GetVoteHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *upvoteSystemClient) WatchCryptos(ctx context.Context, in *WatchCryptosRequest, opts ...grpc.CallOption) (UpvoteSystem_WatchCryptosClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UpvoteSystem_ServiceDesc.Streams[3], "/UpvoteSystem.UpvoteSystem/WatchCryptos", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &upvoteSystemWatchCryptosClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UpvoteSystem_WatchCryptosClient interface {
+	Recv() (*WatchCryptosResponse, error)
+	grpc.ClientStream
+}
+
+type upvoteSystemWatchCryptosClient struct {
+	grpc.ClientStream
+}
+
+func (x *upvoteSystemWatchCryptosClient) Recv() (*WatchCryptosResponse, error) {
+	m := new(WatchCryptosResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *upvoteSystemClient) GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*GetLeaderboardResponse, error) {
+	out := new(GetLeaderboardResponse)
+	err := c.cc.Invoke(ctx, "/UpvoteSystem.UpvoteSystem/GetLeaderboard", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *upvoteSystemClient) WatchLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (UpvoteSystem_WatchLeaderboardClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UpvoteSystem_ServiceDesc.Streams[4], "/UpvoteSystem.UpvoteSystem/WatchLeaderboard", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &upvoteSystemWatchLeaderboardClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UpvoteSystem_WatchLeaderboardClient interface {
+	Recv() (*WatchLeaderboardResponse, error)
+	grpc.ClientStream
+}
+
+type upvoteSystemWatchLeaderboardClient struct {
+	grpc.ClientStream
+}
+
+func (x *upvoteSystemWatchLeaderboardClient) Recv() (*WatchLeaderboardResponse, error) {
+	m := new(WatchLeaderboardResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *upvoteSystemClient) SearchCrypto(ctx context.Context, in *SearchCryptoRequest, opts ...grpc.CallOption) (*SearchCryptoResponse, error) {
+	out := new(SearchCryptoResponse)
+	err := c.cc.Invoke(ctx, "/UpvoteSystem.UpvoteSystem/SearchCrypto", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UpvoteSystemServer is the server API for UpvoteSystem service.
 // All implementations must embed UnimplementedUpvoteSystemServer
 // for forward compatibility
@@ -171,12 +329,21 @@ type UpvoteSystemServer interface {
 	CreateCrypto(context.Context, *CreateCryptoRequest) (*CreateCryptoResponse, error)
 	DeleteCrypto(context.Context, *DeleteCryptoRequest) (*DeleteCryptoResponse, error)
 	ReadCryptoByID(context.Context, *ReadCryptoByIDRequest) (*ReadCryptoByIDResponse, error)
+	ReadCryptoBySymbol(context.Context, *ReadCryptoBySymbolRequest) (*ReadCryptoBySymbolResponse, error)
 	ReadAllCrypto(*ReadAllCryptoRequest, UpvoteSystem_ReadAllCryptoServer) error
 	UpdateCrypto(context.Context, *UpdateCryptoRequest) (*UpdateCryptoResponse, error)
 	UpvoteCrypto(context.Context, *UpvoteCryptoRequest) (*UpvoteCryptoResponse, error)
 	DownvoteCrypto(context.Context, *DownvoteCryptoRequest) (*DownvoteCryptoResponse, error)
+	RetractVote(context.Context, *RetractVoteRequest) (*RetractVoteResponse, error)
+	ChangeVote(context.Context, *ChangeVoteRequest) (*ChangeVoteResponse, error)
+	VoteStream(UpvoteSystem_VoteStreamServer) error
 	GetVotesSum(context.Context, *GetVotesSumRequest) (*GetVotesSumResponse, error)
 	GetVoteSumStream(*GetVoteSumStreamRequest, UpvoteSystem_GetVoteSumStreamServer) error
+	GetVoteHistory(context.Context, *GetVoteHistoryRequest) (*GetVoteHistoryResponse, error)
+	WatchCryptos(*WatchCryptosRequest, UpvoteSystem_WatchCryptosServer) error
+	GetLeaderboard(context.Context, *GetLeaderboardRequest) (*GetLeaderboardResponse, error)
+	WatchLeaderboard(*GetLeaderboardRequest, UpvoteSystem_WatchLeaderboardServer) error
+	SearchCrypto(context.Context, *SearchCryptoRequest) (*SearchCryptoResponse, error)
 	mustEmbedUnimplementedUpvoteSystemServer()
 }
 
@@ -193,6 +360,9 @@ func (UnimplementedUpvoteSystemServer) DeleteCrypto(context.Context, *DeleteCryp
 func (UnimplementedUpvoteSystemServer) ReadCryptoByID(context.Context, *ReadCryptoByIDRequest) (*ReadCryptoByIDResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReadCryptoByID not implemented")
 }
+func (UnimplementedUpvoteSystemServer) ReadCryptoBySymbol(context.Context, *ReadCryptoBySymbolRequest) (*ReadCryptoBySymbolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadCryptoBySymbol not implemented")
+}
 func (UnimplementedUpvoteSystemServer) ReadAllCrypto(*ReadAllCryptoRequest, UpvoteSystem_ReadAllCryptoServer) error {
 	return status.Errorf(codes.Unimplemented, "method ReadAllCrypto not implemented")
 }
@@ -205,12 +375,36 @@ func (UnimplementedUpvoteSystemServer) UpvoteCrypto(context.Context, *UpvoteCryp
 func (UnimplementedUpvoteSystemServer) DownvoteCrypto(context.Context, *DownvoteCryptoRequest) (*DownvoteCryptoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DownvoteCrypto not implemented")
 }
+func (UnimplementedUpvoteSystemServer) RetractVote(context.Context, *RetractVoteRequest) (*RetractVoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetractVote not implemented")
+}
+func (UnimplementedUpvoteSystemServer) ChangeVote(context.Context, *ChangeVoteRequest) (*ChangeVoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeVote not implemented")
+}
+func (UnimplementedUpvoteSystemServer) VoteStream(UpvoteSystem_VoteStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method VoteStream not implemented")
+}
 func (UnimplementedUpvoteSystemServer) GetVotesSum(context.Context, *GetVotesSumRequest) (*GetVotesSumResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetVotesSum not implemented")
 }
 func (UnimplementedUpvoteSystemServer) GetVoteSumStream(*GetVoteSumStreamRequest, UpvoteSystem_GetVoteSumStreamServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetVoteSumStream not implemented")
 }
+func (UnimplementedUpvoteSystemServer) GetVoteHistory(context.Context, *GetVoteHistoryRequest) (*GetVoteHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVoteHistory not implemented")
+}
+func (UnimplementedUpvoteSystemServer) WatchCryptos(*WatchCryptosRequest, UpvoteSystem_WatchCryptosServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchCryptos not implemented")
+}
+func (UnimplementedUpvoteSystemServer) GetLeaderboard(context.Context, *GetLeaderboardRequest) (*GetLeaderboardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLeaderboard not implemented")
+}
+func (UnimplementedUpvoteSystemServer) WatchLeaderboard(*GetLeaderboardRequest, UpvoteSystem_WatchLeaderboardServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchLeaderboard not implemented")
+}
+func (UnimplementedUpvoteSystemServer) SearchCrypto(context.Context, *SearchCryptoRequest) (*SearchCryptoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchCrypto not implemented")
+}
 func (UnimplementedUpvoteSystemServer) mustEmbedUnimplementedUpvoteSystemServer() {}
 
 // UnsafeUpvoteSystemServer may be embedded to opt out of forward compatibility for this service.
@@ -278,6 +472,24 @@ func _UpvoteSystem_ReadCryptoByID_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UpvoteSystem_ReadCryptoBySymbol_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadCryptoBySymbolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UpvoteSystemServer).ReadCryptoBySymbol(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/UpvoteSystem.UpvoteSystem/ReadCryptoBySymbol",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UpvoteSystemServer).ReadCryptoBySymbol(ctx, req.(*ReadCryptoBySymbolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UpvoteSystem_ReadAllCrypto_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(ReadAllCryptoRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -353,6 +565,68 @@ func _UpvoteSystem_DownvoteCrypto_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UpvoteSystem_RetractVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetractVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UpvoteSystemServer).RetractVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/UpvoteSystem.UpvoteSystem/RetractVote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UpvoteSystemServer).RetractVote(ctx, req.(*RetractVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UpvoteSystem_ChangeVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UpvoteSystemServer).ChangeVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/UpvoteSystem.UpvoteSystem/ChangeVote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UpvoteSystemServer).ChangeVote(ctx, req.(*ChangeVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UpvoteSystem_VoteStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(UpvoteSystemServer).VoteStream(&upvoteSystemVoteStreamServer{stream})
+}
+
+type UpvoteSystem_VoteStreamServer interface {
+	Send(*VoteAck) error
+	Recv() (*VoteCommand, error)
+	grpc.ServerStream
+}
+
+type upvoteSystemVoteStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *upvoteSystemVoteStreamServer) Send(m *VoteAck) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *upvoteSystemVoteStreamServer) Recv() (*VoteCommand, error) {
+	m := new(VoteCommand)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _UpvoteSystem_GetVotesSum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetVotesSumRequest)
 	if err := dec(in); err != nil {
@@ -392,6 +666,102 @@ func (x *upvoteSystemGetVoteSumStreamServer) Send(m *GetVoteSumStreamResponse) e
 	return x.ServerStream.SendMsg(m)
 }
 
+func _UpvoteSystem_GetVoteHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVoteHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UpvoteSystemServer).GetVoteHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/UpvoteSystem.UpvoteSystem/GetVoteHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UpvoteSystemServer).GetVoteHistory(ctx, req.(*GetVoteHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UpvoteSystem_WatchCryptos_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchCryptosRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UpvoteSystemServer).WatchCryptos(m, &upvoteSystemWatchCryptosServer{stream})
+}
+
+type UpvoteSystem_WatchCryptosServer interface {
+	Send(*WatchCryptosResponse) error
+	grpc.ServerStream
+}
+
+type upvoteSystemWatchCryptosServer struct {
+	grpc.ServerStream
+}
+
+func (x *upvoteSystemWatchCryptosServer) Send(m *WatchCryptosResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UpvoteSystem_GetLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UpvoteSystemServer).GetLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/UpvoteSystem.UpvoteSystem/GetLeaderboard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UpvoteSystemServer).GetLeaderboard(ctx, req.(*GetLeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UpvoteSystem_WatchLeaderboard_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetLeaderboardRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UpvoteSystemServer).WatchLeaderboard(m, &upvoteSystemWatchLeaderboardServer{stream})
+}
+
+type UpvoteSystem_WatchLeaderboardServer interface {
+	Send(*WatchLeaderboardResponse) error
+	grpc.ServerStream
+}
+
+type upvoteSystemWatchLeaderboardServer struct {
+	grpc.ServerStream
+}
+
+func (x *upvoteSystemWatchLeaderboardServer) Send(m *WatchLeaderboardResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UpvoteSystem_SearchCrypto_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchCryptoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UpvoteSystemServer).SearchCrypto(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/UpvoteSystem.UpvoteSystem/SearchCrypto",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UpvoteSystemServer).SearchCrypto(ctx, req.(*SearchCryptoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UpvoteSystem_ServiceDesc is the grpc.ServiceDesc for UpvoteSystem service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -411,6 +781,10 @@ var UpvoteSystem_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReadCryptoByID",
 			Handler:    _UpvoteSystem_ReadCryptoByID_Handler,
 		},
+		{
+			MethodName: "ReadCryptoBySymbol",
+			Handler:    _UpvoteSystem_ReadCryptoBySymbol_Handler,
+		},
 		{
 			MethodName: "UpdateCrypto",
 			Handler:    _UpvoteSystem_UpdateCrypto_Handler,
@@ -423,10 +797,30 @@ var UpvoteSystem_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DownvoteCrypto",
 			Handler:    _UpvoteSystem_DownvoteCrypto_Handler,
 		},
+		{
+			MethodName: "RetractVote",
+			Handler:    _UpvoteSystem_RetractVote_Handler,
+		},
+		{
+			MethodName: "ChangeVote",
+			Handler:    _UpvoteSystem_ChangeVote_Handler,
+		},
 		{
 			MethodName: "GetVotesSum",
 			Handler:    _UpvoteSystem_GetVotesSum_Handler,
 		},
+		{
+			MethodName: "GetVoteHistory",
+			Handler:    _UpvoteSystem_GetVoteHistory_Handler,
+		},
+		{
+			MethodName: "GetLeaderboard",
+			Handler:    _UpvoteSystem_GetLeaderboard_Handler,
+		},
+		{
+			MethodName: "SearchCrypto",
+			Handler:    _UpvoteSystem_SearchCrypto_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -434,11 +828,27 @@ var UpvoteSystem_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _UpvoteSystem_ReadAllCrypto_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "VoteStream",
+			Handler:       _UpvoteSystem_VoteStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "GetVoteSumStream",
 			Handler:       _UpvoteSystem_GetVoteSumStream_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "WatchCryptos",
+			Handler:       _UpvoteSystem_WatchCryptos_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchLeaderboard",
+			Handler:       _UpvoteSystem_WatchLeaderboard_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "proto/UpvoteSystem.proto",
 }
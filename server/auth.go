@@ -0,0 +1,422 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// authorizationMetadataKey - Request metadata carrying "Bearer <jwt>"
+	authorizationMetadataKey = "authorization"
+	// apiKeyMetadataKey - Request metadata carrying an API key
+	apiKeyMetadataKey = "x-api-key"
+
+	// jwtLeeway - Clock difference with the token issuer tolerated on exp and nbf
+	jwtLeeway = 30 * time.Second
+	// minJWTSecretLength - Shortest HS256 secret, in bytes
+	minJWTSecretLength = 32
+)
+
+// Authentication methods of a Principal
+const (
+	authMethodJWT    = "jwt"
+	authMethodAPIKey = "api-key"
+	authMethodCert   = "certificate"
+)
+
+// errInvalidCredentials - Rejects malformed, expired or wrongly signed credentials
+var errInvalidCredentials = errors.New("invalid credentials")
+
+// Principal - Caller authenticated by a JWT subject, the name of an API key or the common name of a
+// client certificate, with the roles granted by the roles claim or the configuration
+type Principal struct {
+	ID     string
+	Method string
+	Roles  []Role
+}
+
+type principalKey struct{}
+
+// withPrincipal - Returns a copy of ctx carrying principal
+func withPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// principalFromContext - Principal attached to ctx by the auth interceptors
+func principalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// authenticator - Validates the credentials of the calls, a JWT signed with the HMAC secret or one
+// of the Ed25519 keys, or an API key whose SHA-256 hash is known. Secrets are never stored in clear
+type authenticator struct {
+	jwtSecret   []byte
+	jwtKeys     []ed25519.PublicKey
+	jwtIssuer   string
+	jwtAudience string
+	// apiKeys - Principal of each API key by the SHA-256 hash of the key
+	apiKeys map[[sha256.Size]byte]Principal
+	// certPrincipals - Principal of each verified client certificate by its subject common name
+	certPrincipals map[string]Principal
+
+	now func() time.Time
+}
+
+func newAuthenticator() *authenticator {
+	return &authenticator{
+		apiKeys:        make(map[[sha256.Size]byte]Principal),
+		certPrincipals: make(map[string]Principal),
+		now:            time.Now,
+	}
+}
+
+// loadAuthenticator - Reads the accepted credentials from the environment:
+// AUTH_JWT_SECRET, the HS256 secret; AUTH_JWT_PUBLIC_KEYS, a PEM file of Ed25519 public keys;
+// AUTH_JWT_ISSUER and AUTH_JWT_AUDIENCE, the required iss and aud claims; AUTH_API_KEYS,
+// comma-separated name:sha256-hex or name:role|role:sha256-hex entries; and AUTH_CERT_PRINCIPALS,
+// comma-separated common-name or common-name:role|role entries of client certificates
+func loadAuthenticator() (*authenticator, error) {
+	a := newAuthenticator()
+	a.jwtIssuer = os.Getenv("AUTH_JWT_ISSUER")
+	a.jwtAudience = os.Getenv("AUTH_JWT_AUDIENCE")
+
+	if secret := os.Getenv("AUTH_JWT_SECRET"); secret != "" {
+		if len(secret) < minJWTSecretLength {
+			return nil, fmt.Errorf("AUTH_JWT_SECRET must be at least %d bytes", minJWTSecretLength)
+		}
+		a.jwtSecret = []byte(secret)
+	}
+
+	if path := os.Getenv("AUTH_JWT_PUBLIC_KEYS"); path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if a.jwtKeys, err = parseEd25519Keys(data); err != nil {
+			return nil, fmt.Errorf("AUTH_JWT_PUBLIC_KEYS: %v", err)
+		}
+	}
+
+	if value := os.Getenv("AUTH_API_KEYS"); value != "" {
+		for _, entry := range strings.Split(value, ",") {
+			if err := a.addAPIKeyHash(strings.TrimSpace(entry)); err != nil {
+				return nil, fmt.Errorf("AUTH_API_KEYS: %v", err)
+			}
+		}
+	}
+
+	if value := os.Getenv("AUTH_CERT_PRINCIPALS"); value != "" {
+		for _, entry := range strings.Split(value, ",") {
+			if err := a.addCertPrincipal(strings.TrimSpace(entry)); err != nil {
+				return nil, fmt.Errorf("AUTH_CERT_PRINCIPALS: %v", err)
+			}
+		}
+	}
+
+	if !a.configured() {
+		return nil, errors.New("no credentials configured, set AUTH_JWT_SECRET, AUTH_JWT_PUBLIC_KEYS, AUTH_API_KEYS or AUTH_CERT_PRINCIPALS")
+	}
+	return a, nil
+}
+
+// parseEd25519Keys - Public keys of the PKIX "PUBLIC KEY" blocks of a PEM file
+func parseEd25519Keys(data []byte) ([]ed25519.PublicKey, error) {
+	var keys []ed25519.PublicKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "PUBLIC KEY" {
+			continue
+		}
+
+		parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key, ok := parsed.(ed25519.PublicKey)
+		if !ok {
+			return nil, errors.New("not an Ed25519 public key")
+		}
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no public keys found")
+	}
+	return keys, nil
+}
+
+// addAPIKeyHash - Accepts the API key hashed in a name:sha256-hex entry, or name:role|role:sha256-hex
+// to grant other roles than voter
+func (a *authenticator) addAPIKeyHash(entry string) error {
+	fields := strings.Split(entry, ":")
+	if len(fields) < 2 || len(fields) > 3 || fields[0] == "" {
+		return fmt.Errorf("invalid entry %q", entry)
+	}
+
+	hash, err := hex.DecodeString(fields[len(fields)-1])
+	if err != nil || len(hash) != sha256.Size {
+		return fmt.Errorf("invalid hash of %q", fields[0])
+	}
+
+	principal := Principal{ID: fields[0], Method: authMethodAPIKey, Roles: defaultRoles}
+	if len(fields) == 3 {
+		if principal.Roles, err = parseRoles(strings.Split(fields[1], "|")); err != nil {
+			return fmt.Errorf("%v of %q", err, fields[0])
+		}
+	}
+
+	var key [sha256.Size]byte
+	copy(key[:], hash)
+	a.apiKeys[key] = principal
+	return nil
+}
+
+// addCertPrincipal - Accepts the client certificates of a common-name or common-name:role|role entry
+func (a *authenticator) addCertPrincipal(entry string) error {
+	fields := strings.Split(entry, ":")
+	if len(fields) > 2 || fields[0] == "" {
+		return fmt.Errorf("invalid entry %q", entry)
+	}
+
+	principal := Principal{ID: fields[0], Method: authMethodCert, Roles: defaultRoles}
+	if len(fields) == 2 {
+		var err error
+		if principal.Roles, err = parseRoles(strings.Split(fields[1], "|")); err != nil {
+			return fmt.Errorf("%v of %q", err, fields[0])
+		}
+	}
+	a.certPrincipals[fields[0]] = principal
+	return nil
+}
+
+func (a *authenticator) configured() bool {
+	return len(a.jwtSecret) > 0 || len(a.jwtKeys) > 0 || len(a.apiKeys) > 0 || len(a.certPrincipals) > 0
+}
+
+// authenticate - Principal of the credentials in the metadata of ctx, or else of the verified client
+// certificate of the connection. Gateways authenticated by certificate forward the credentials of
+// their callers, which take precedence
+func (a *authenticator) authenticate(ctx context.Context) (Principal, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if values := md.Get(authorizationMetadataKey); len(values) > 0 {
+		scheme, token := splitAuthorization(values[0])
+		if !strings.EqualFold(scheme, "bearer") || token == "" {
+			return Principal{}, status.Errorf(codes.Unauthenticated, "Invalid authorization")
+		}
+		claims, err := a.verifyJWT(token)
+		if err != nil {
+			return Principal{}, status.Errorf(codes.Unauthenticated, "Invalid token")
+		}
+
+		principal := Principal{ID: claims.Subject, Method: authMethodJWT, Roles: defaultRoles}
+		if claims.Roles != nil {
+			if principal.Roles, err = parseRoles(claims.Roles); err != nil {
+				return Principal{}, status.Errorf(codes.Unauthenticated, "Invalid token")
+			}
+		}
+		return principal, nil
+	}
+
+	if values := md.Get(apiKeyMetadataKey); len(values) > 0 {
+		principal, found := a.apiKeys[sha256.Sum256([]byte(values[0]))]
+		if !found {
+			return Principal{}, status.Errorf(codes.Unauthenticated, "Invalid API key")
+		}
+		return principal, nil
+	}
+
+	if commonName, verified := peerCommonName(ctx); verified {
+		principal, found := a.certPrincipals[commonName]
+		if !found {
+			return Principal{}, status.Errorf(codes.Unauthenticated, "Unknown certificate")
+		}
+		return principal, nil
+	}
+
+	return Principal{}, status.Errorf(codes.Unauthenticated, "Missing credentials")
+}
+
+// peerCommonName - Subject common name of the client certificate of the connection of ctx, only
+// reported when TLS verified it against the client CA bundle
+func peerCommonName(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+	return info.State.VerifiedChains[0][0].Subject.CommonName, true
+}
+
+func splitAuthorization(value string) (scheme string, credentials string) {
+	parts := strings.SplitN(strings.TrimSpace(value), " ", 2)
+	if len(parts) < 2 {
+		return parts[0], ""
+	}
+	return parts[0], strings.TrimSpace(parts[1])
+}
+
+// jwtHeader - JOSE header of a token
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+}
+
+// jwtClaims - Registered claims checked on every token and the roles of the subject
+type jwtClaims struct {
+	Subject   string      `json:"sub"`
+	Issuer    string      `json:"iss"`
+	Audience  jwtAudience `json:"aud"`
+	ExpiresAt int64       `json:"exp"`
+	NotBefore int64       `json:"nbf"`
+	Roles     []string    `json:"roles"`
+}
+
+// jwtAudience - aud claim, a single string or an array of them
+type jwtAudience []string
+
+func (audience *jwtAudience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*audience = jwtAudience{single}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(audience))
+}
+
+func (audience jwtAudience) contains(value string) bool {
+	for _, candidate := range audience {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyJWT - Claims of a compact JWT signed with HS256 or EdDSA by a configured key. Tokens must
+// expire, the algorithm must match a configured key so an Ed25519 public key is never used as secret
+func (a *authenticator) verifyJWT(token string) (jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return jwtClaims{}, errInvalidCredentials
+	}
+
+	var header jwtHeader
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return jwtClaims{}, err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return jwtClaims{}, errInvalidCredentials
+	}
+
+	signed := []byte(parts[0] + "." + parts[1])
+	if !a.validSignature(header.Algorithm, signed, signature) {
+		return jwtClaims{}, errInvalidCredentials
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return jwtClaims{}, err
+	}
+
+	now := a.now()
+	switch {
+	case claims.Subject == "":
+		return jwtClaims{}, errInvalidCredentials
+	case claims.ExpiresAt == 0 || now.After(time.Unix(claims.ExpiresAt, 0).Add(jwtLeeway)):
+		return jwtClaims{}, errInvalidCredentials
+	case claims.NotBefore != 0 && now.Add(jwtLeeway).Before(time.Unix(claims.NotBefore, 0)):
+		return jwtClaims{}, errInvalidCredentials
+	case a.jwtIssuer != "" && claims.Issuer != a.jwtIssuer:
+		return jwtClaims{}, errInvalidCredentials
+	case a.jwtAudience != "" && !claims.Audience.contains(a.jwtAudience):
+		return jwtClaims{}, errInvalidCredentials
+	}
+	return claims, nil
+}
+
+func (a *authenticator) validSignature(algorithm string, signed []byte, signature []byte) bool {
+	switch algorithm {
+	case "HS256":
+		if len(a.jwtSecret) == 0 {
+			return false
+		}
+		mac := hmac.New(sha256.New, a.jwtSecret)
+		mac.Write(signed)
+		return hmac.Equal(mac.Sum(nil), signature)
+	case "EdDSA":
+		for _, key := range a.jwtKeys {
+			if ed25519.Verify(key, signed, signature) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func decodeJWTPart(part string, value interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return errInvalidCredentials
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		return errInvalidCredentials
+	}
+	return nil
+}
+
+// unaryInterceptor - Rejects unauthenticated unary calls and passes the Principal to the handler
+func (a *authenticator) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	principal, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(withPrincipal(ctx, principal), req)
+}
+
+// streamInterceptor - Rejects unauthenticated streams and passes the Principal to the handler
+func (a *authenticator) streamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	principal, err := a.authenticate(stream.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, authenticatedStream{ServerStream: stream, ctx: withPrincipal(stream.Context(), principal)})
+}
+
+// authenticatedStream - ServerStream whose context carries the Principal
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s authenticatedStream) Context() context.Context {
+	return s.ctx
+}
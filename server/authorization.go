@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	upvoteSystem "github.com/RomuloSiebra/CryptoUpvoteSystem/proto/UpvoteSystem"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Role - Permission group of a Principal
+type Role string
+
+const (
+	// roleVoter - Reads cryptocurrencies and votes on them
+	roleVoter Role = "voter"
+	// roleAdmin - Also creates, updates and deletes cryptocurrencies
+	roleAdmin Role = "admin"
+)
+
+// defaultRoles - Roles of tokens without a roles claim and API keys configured without roles
+var defaultRoles = []Role{roleVoter}
+
+// Roles allowed to call each kind of method
+var (
+	readRoles  = []Role{roleVoter, roleAdmin}
+	voteRoles  = []Role{roleVoter, roleAdmin}
+	adminRoles = []Role{roleAdmin}
+)
+
+// methodPermissions - Roles allowed to call each method, by gRPC full method name. Methods missing
+// from the table are denied to everyone
+var methodPermissions = map[string][]Role{
+	upvoteSystemMethod("CreateCrypto"):       adminRoles,
+	upvoteSystemMethod("UpdateCrypto"):       adminRoles,
+	upvoteSystemMethod("DeleteCrypto"):       adminRoles,
+	upvoteSystemMethod("UpvoteCrypto"):       voteRoles,
+	upvoteSystemMethod("DownvoteCrypto"):     voteRoles,
+	upvoteSystemMethod("RetractVote"):        voteRoles,
+	upvoteSystemMethod("ChangeVote"):         voteRoles,
+	upvoteSystemMethod("VoteStream"):         voteRoles,
+	upvoteSystemMethod("ReadCryptoByID"):     readRoles,
+	upvoteSystemMethod("ReadCryptoBySymbol"): readRoles,
+	upvoteSystemMethod("ReadAllCrypto"):      readRoles,
+	upvoteSystemMethod("SearchCrypto"):       readRoles,
+	upvoteSystemMethod("GetVotesSum"):        readRoles,
+	upvoteSystemMethod("GetVoteSumStream"):   readRoles,
+	upvoteSystemMethod("GetVoteHistory"):     readRoles,
+	upvoteSystemMethod("WatchCryptos"):       readRoles,
+	upvoteSystemMethod("GetLeaderboard"):     readRoles,
+	upvoteSystemMethod("WatchLeaderboard"):   readRoles,
+
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo": readRoles,
+}
+
+// upvoteSystemMethod - gRPC full method name of an UpvoteSystem method
+func upvoteSystemMethod(name string) string {
+	return "/" + upvoteSystem.UpvoteSystem_ServiceDesc.ServiceName + "/" + name
+}
+
+// parseRoles - Converts role names, rejecting unknown ones
+func parseRoles(names []string) ([]Role, error) {
+	roles := make([]Role, 0, len(names))
+	for _, name := range names {
+		switch role := Role(name); role {
+		case roleVoter, roleAdmin:
+			roles = append(roles, role)
+		default:
+			return nil, fmt.Errorf("unknown role %q", name)
+		}
+	}
+	return roles, nil
+}
+
+// authorize - Checks the Principal of ctx has a role allowed to call method
+func authorize(ctx context.Context, method string) error {
+	principal, ok := principalFromContext(ctx)
+	if !ok {
+		return status.Errorf(codes.Unauthenticated, "Missing credentials")
+	}
+
+	for _, allowed := range methodPermissions[method] {
+		for _, role := range principal.Roles {
+			if role == allowed {
+				return nil
+			}
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "Permission denied")
+}
+
+// authorizationUnaryInterceptor - Rejects unary calls of principals without an allowed role, runs
+// after the authenticator interceptor
+func authorizationUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// authorizationStreamInterceptor - Rejects streams of principals without an allowed role, runs
+// after the authenticator interceptor
+func authorizationStreamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := authorize(stream.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
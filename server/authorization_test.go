@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	upvoteSystem "github.com/RomuloSiebra/CryptoUpvoteSystem/proto/UpvoteSystem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthorizeEveryMethod(t *testing.T) {
+	// Whether voters may call each method, admins may call all of them
+	voterAllowed := map[string]bool{
+		"CreateCrypto":       false,
+		"UpdateCrypto":       false,
+		"DeleteCrypto":       false,
+		"UpvoteCrypto":       true,
+		"DownvoteCrypto":     true,
+		"RetractVote":        true,
+		"ChangeVote":         true,
+		"VoteStream":         true,
+		"ReadCryptoByID":     true,
+		"ReadCryptoBySymbol": true,
+		"ReadAllCrypto":      true,
+		"SearchCrypto":       true,
+		"GetVotesSum":        true,
+		"GetVoteSumStream":   true,
+		"GetVoteHistory":     true,
+		"WatchCryptos":       true,
+		"GetLeaderboard":     true,
+		"WatchLeaderboard":   true,
+	}
+
+	var methods []string
+	for _, method := range upvoteSystem.UpvoteSystem_ServiceDesc.Methods {
+		methods = append(methods, method.MethodName)
+	}
+	for _, stream := range upvoteSystem.UpvoteSystem_ServiceDesc.Streams {
+		methods = append(methods, stream.StreamName)
+	}
+	require.Len(t, methods, len(voterAllowed))
+
+	voter := withPrincipal(context.Background(), Principal{ID: "alice", Roles: []Role{roleVoter}})
+	admin := withPrincipal(context.Background(), Principal{ID: "bob", Roles: []Role{roleAdmin}})
+	noRoles := withPrincipal(context.Background(), Principal{ID: "carol"})
+
+	for _, method := range methods {
+		allowed, listed := voterAllowed[method]
+		require.True(t, listed, "missing expectation of %s", method)
+		require.Contains(t, methodPermissions, upvoteSystemMethod(method), "missing permission of %s", method)
+
+		fullMethod := upvoteSystemMethod(method)
+		assert.Nil(t, authorize(admin, fullMethod), method)
+		assert.Equal(t, codes.PermissionDenied, status.Code(authorize(noRoles, fullMethod)), method)
+		assert.Equal(t, codes.Unauthenticated, status.Code(authorize(context.Background(), fullMethod)), method)
+		if allowed {
+			assert.Nil(t, authorize(voter, fullMethod), method)
+		} else {
+			assert.Equal(t, codes.PermissionDenied, status.Code(authorize(voter, fullMethod)), method)
+		}
+	}
+
+	// Test methods missing from the table are denied
+	assert.Equal(t, codes.PermissionDenied, status.Code(authorize(admin, upvoteSystemMethod("DropDatabase"))))
+}
+
+func TestAuthorizationInterceptors(t *testing.T) {
+	voter := withPrincipal(context.Background(), Principal{ID: "alice", Roles: []Role{roleVoter}})
+	called := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		return nil, nil
+	}
+
+	// Test the handler doesn't run for denied calls
+	_, err := authorizationUnaryInterceptor(voter, nil, &grpc.UnaryServerInfo{FullMethod: upvoteSystemMethod("DeleteCrypto")}, handler)
+	require.NotNil(t, err)
+	assert.Equal(t, "rpc error: code = PermissionDenied desc = Permission denied", err.Error())
+	assert.False(t, called)
+
+	_, err = authorizationUnaryInterceptor(voter, nil, &grpc.UnaryServerInfo{FullMethod: upvoteSystemMethod("UpvoteCrypto")}, handler)
+	require.Nil(t, err)
+	assert.True(t, called)
+
+	called = false
+	streamHandler := func(srv interface{}, stream grpc.ServerStream) error {
+		called = true
+		return nil
+	}
+
+	err = authorizationStreamInterceptor(nil, contextStream{ctx: voter}, &grpc.StreamServerInfo{FullMethod: upvoteSystemMethod("ReadAllCrypto")}, streamHandler)
+	require.Nil(t, err)
+	assert.True(t, called)
+}
@@ -0,0 +1,440 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Bolt buckets, cryptoBucket maps ObjectID bytes to the BSON document,
+// cryptoNameBucket and cryptoSymbolBucket map each lowercase name and symbol
+// to its ObjectID to enforce uniqueness,
+// voteBucket maps ObjectID bytes followed by the voter ID to the BSON vote,
+// snapshotBucket maps ObjectID bytes followed by the snapshot time to the BSON
+// snapshot and snapshotTimeBucket keeps every snapshot time for quick lookups,
+// nonceBucket maps the signing key, a zero byte and the nonce to its expiry time
+var (
+	cryptoBucket       = []byte("Cryptocurrency")
+	cryptoNameBucket   = []byte("CryptocurrencyName")
+	cryptoSymbolBucket = []byte("CryptocurrencySymbol")
+	voteBucket         = []byte("Vote")
+	snapshotBucket     = []byte("Snapshot")
+	snapshotTimeBucket = []byte("SnapshotTime")
+	nonceBucket        = []byte("VoteNonce")
+)
+
+// boltRepository - CryptoRepository persisted in an embedded bbolt file
+type boltRepository struct {
+	db           *bolt.DB
+	path         string
+	nonceSweeper sweeper
+}
+
+// openBoltRepository - Opens or creates the bbolt file at path
+func openBoltRepository(path string) (*boltRepository, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{cryptoBucket, voteBucket, snapshotBucket, snapshotTimeBucket, nonceBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return rebuildUniqueBuckets(tx)
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &boltRepository{db: db, path: path}, nil
+}
+
+// Close releases the file lock held by the bbolt database
+func (r *boltRepository) Close() error {
+	return r.db.Close()
+}
+
+// rebuildUniqueBuckets - Fills the name and symbol buckets from the stored cryptocurrencies, so files
+// written before names were unique ignoring case are checked when opened. Their conflicting names and
+// symbols are changed by resolveCaseConflicts first
+func rebuildUniqueBuckets(tx *bolt.Tx) error {
+	for _, bucket := range [][]byte{cryptoNameBucket, cryptoSymbolBucket} {
+		if tx.Bucket(bucket) != nil {
+			if err := tx.DeleteBucket(bucket); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.CreateBucket(bucket); err != nil {
+			return err
+		}
+	}
+
+	// Keys are ObjectID bytes, ForEach visits the oldest cryptocurrencies first
+	var cryptos []model.Crypto
+	err := tx.Bucket(cryptoBucket).ForEach(func(key []byte, value []byte) error {
+		crypto := model.Crypto{}
+		if err := bson.Unmarshal(value, &crypto); err != nil {
+			return err
+		}
+		cryptos = append(cryptos, crypto)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	renamed := make(map[primitive.ObjectID]model.Crypto)
+	for _, crypto := range resolveCaseConflicts(cryptos) {
+		if err := putCrypto(tx, crypto); err != nil {
+			return err
+		}
+		renamed[crypto.ID] = crypto
+	}
+
+	for _, crypto := range cryptos {
+		if changed, found := renamed[crypto.ID]; found {
+			crypto = changed
+		}
+		if err := claimUnique(tx, model.Crypto{}, crypto); err != nil {
+			return fmt.Errorf("cryptocurrency %s: %v", crypto.ID.Hex(), err)
+		}
+	}
+	return nil
+}
+
+// claimUnique - Moves the name and symbol keys of stored to the ones of next, failing with
+// ErrAlreadyExists when another cryptocurrency holds them
+func claimUnique(tx *bolt.Tx, stored model.Crypto, next model.Crypto) error {
+	claims := []struct {
+		bucket   []byte
+		previous string
+		next     string
+	}{
+		{cryptoNameBucket, stored.Name, next.Name},
+		{cryptoSymbolBucket, stored.Symbol, next.Symbol},
+	}
+
+	for _, claim := range claims {
+		bucket := tx.Bucket(claim.bucket)
+		previous, key := uniqueKey(claim.previous), uniqueKey(claim.next)
+		if previous == key {
+			continue
+		}
+
+		if key != "" {
+			if owner := bucket.Get([]byte(key)); owner != nil && !bytes.Equal(owner, next.ID[:]) {
+				return ErrAlreadyExists
+			}
+			if err := bucket.Put([]byte(key), next.ID[:]); err != nil {
+				return err
+			}
+		}
+		if previous != "" {
+			if err := bucket.Delete([]byte(previous)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *boltRepository) Create(ctx context.Context, crypto model.Crypto) (model.Crypto, error) {
+	if crypto.ID.IsZero() {
+		crypto.ID = primitive.NewObjectID()
+	}
+
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		if err := claimUnique(tx, model.Crypto{}, crypto); err != nil {
+			return err
+		}
+		return putCrypto(tx, crypto)
+	})
+	if err != nil {
+		return model.Crypto{}, err
+	}
+	return crypto, nil
+}
+
+func (r *boltRepository) GetByID(ctx context.Context, id primitive.ObjectID) (model.Crypto, error) {
+	var crypto model.Crypto
+
+	err := r.db.View(func(tx *bolt.Tx) error {
+		var err error
+		crypto, err = getCrypto(tx, id)
+		return err
+	})
+	return crypto, err
+}
+
+func (r *boltRepository) GetBySymbol(ctx context.Context, symbol string) (model.Crypto, error) {
+	var crypto model.Crypto
+
+	err := r.db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(cryptoSymbolBucket).Get([]byte(uniqueKey(symbol)))
+		if symbol == "" || value == nil {
+			return ErrNotFound
+		}
+
+		var id primitive.ObjectID
+		copy(id[:], value)
+
+		var err error
+		crypto, err = getCrypto(tx, id)
+		return err
+	})
+	return crypto, err
+}
+
+// List decodes every cryptocurrency and applies query in memory
+func (r *boltRepository) List(ctx context.Context, query ListQuery) ([]model.Crypto, error) {
+	var result []model.Crypto
+
+	err := r.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(cryptoBucket).ForEach(func(key []byte, value []byte) error {
+			crypto := model.Crypto{}
+			if err := bson.Unmarshal(value, &crypto); err != nil {
+				return err
+			}
+			result = append(result, crypto)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return query.apply(result), nil
+}
+
+func (r *boltRepository) Update(ctx context.Context, crypto model.Crypto) (model.Crypto, error) {
+	var updated model.Crypto
+
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		stored, err := getCrypto(tx, crypto.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := claimUnique(tx, stored, crypto); err != nil {
+			return err
+		}
+
+		updated = withDetails(stored, crypto)
+		return putCrypto(tx, updated)
+	})
+	if err != nil {
+		return model.Crypto{}, err
+	}
+	return updated, nil
+}
+
+func (r *boltRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		crypto, err := getCrypto(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := claimUnique(tx, crypto, model.Crypto{ID: id}); err != nil {
+			return err
+		}
+
+		votes := tx.Bucket(voteBucket).Cursor()
+		for key, _ := votes.Seek(id[:]); key != nil && bytes.HasPrefix(key, id[:]); key, _ = votes.Seek(id[:]) {
+			if err := votes.Delete(); err != nil {
+				return err
+			}
+		}
+		return tx.Bucket(cryptoBucket).Delete(id[:])
+	})
+}
+
+func (r *boltRepository) UpdateVote(ctx context.Context, id primitive.ObjectID, voterID string, decide VoteDecision) (model.Crypto, error) {
+	var crypto model.Crypto
+
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		var err error
+		crypto, err = getCrypto(tx, id)
+		if err != nil {
+			return err
+		}
+
+		votes := tx.Bucket(voteBucket)
+		key := append(id[:], voterID...)
+
+		previous := model.Vote{}
+		if value := votes.Get(key); value != nil {
+			if err := bson.Unmarshal(value, &previous); err != nil {
+				return err
+			}
+		}
+
+		next, err := decide(previous.Direction)
+		if err != nil {
+			return err
+		}
+		if next == previous.Direction {
+			return nil
+		}
+
+		now := time.Now()
+		if next == model.NoVote {
+			err = votes.Delete(key)
+		} else {
+			var value []byte
+			value, err = bson.Marshal(model.Vote{CryptoID: id, VoterID: voterID, Direction: next, UpdatedAt: now})
+			if err == nil {
+				err = votes.Put(key, value)
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		upvote, downvote := voteDelta(previous.Direction, next)
+		crypto.Upvote += upvote
+		crypto.Downvote += downvote
+		crypto.LastVoteAt = now
+		return putCrypto(tx, crypto)
+	})
+	if err != nil {
+		return model.Crypto{}, err
+	}
+	return crypto, nil
+}
+
+func (r *boltRepository) SaveSnapshots(ctx context.Context, snapshots []model.Snapshot) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		for _, snapshot := range snapshots {
+			timeKey := snapshotTimeKey(snapshot.Timestamp)
+			key := append(snapshot.CryptoID[:], timeKey...)
+			if tx.Bucket(snapshotBucket).Get(key) != nil {
+				continue
+			}
+
+			value, err := bson.Marshal(snapshot)
+			if err != nil {
+				return err
+			}
+			if err := tx.Bucket(snapshotBucket).Put(key, value); err != nil {
+				return err
+			}
+			if err := tx.Bucket(snapshotTimeBucket).Put(timeKey, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *boltRepository) LastSnapshotTime(ctx context.Context) (time.Time, error) {
+	var last time.Time
+
+	err := r.db.View(func(tx *bolt.Tx) error {
+		if key, _ := tx.Bucket(snapshotTimeBucket).Cursor().Last(); key != nil {
+			last = time.Unix(0, int64(binary.BigEndian.Uint64(key))).UTC()
+		}
+		return nil
+	})
+	return last, err
+}
+
+func (r *boltRepository) ListSnapshots(ctx context.Context, id primitive.ObjectID, from time.Time, to time.Time) ([]model.Snapshot, error) {
+	var result []model.Snapshot
+
+	err := r.db.View(func(tx *bolt.Tx) error {
+		last := append(id[:], snapshotTimeKey(to)...)
+
+		cursor := tx.Bucket(snapshotBucket).Cursor()
+		for key, value := cursor.Seek(append(id[:], snapshotTimeKey(from)...)); key != nil && bytes.Compare(key, last) <= 0; key, value = cursor.Next() {
+			snapshot := model.Snapshot{}
+			if err := bson.Unmarshal(value, &snapshot); err != nil {
+				return err
+			}
+			result = append(result, snapshot)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// snapshotTimeKey - Big endian Unix nanoseconds so keys sort chronologically,
+// times before the Unix epoch map to the first key
+func snapshotTimeKey(timestamp time.Time) []byte {
+	key := make([]byte, 8)
+	if timestamp.After(time.Unix(0, 0)) {
+		binary.BigEndian.PutUint64(key, uint64(timestamp.UnixNano()))
+	}
+	return key
+}
+
+func getCrypto(tx *bolt.Tx, id primitive.ObjectID) (model.Crypto, error) {
+	value := tx.Bucket(cryptoBucket).Get(id[:])
+	if value == nil {
+		return model.Crypto{}, ErrNotFound
+	}
+
+	crypto := model.Crypto{}
+	if err := bson.Unmarshal(value, &crypto); err != nil {
+		return model.Crypto{}, err
+	}
+	return crypto, nil
+}
+
+func putCrypto(tx *bolt.Tx, crypto model.Crypto) error {
+	value, err := bson.Marshal(crypto)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(cryptoBucket).Put(crypto.ID[:], value)
+}
+
+func (r *boltRepository) UseNonce(ctx context.Context, key string, nonce string, expires time.Time) error {
+	now := time.Now()
+	sweep := r.nonceSweeper.due(now)
+
+	return r.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(nonceBucket)
+		if sweep {
+			var expired [][]byte
+			err := bucket.ForEach(func(nonceKey []byte, value []byte) error {
+				if nonceExpiry(value).Before(now) {
+					expired = append(expired, nonceKey)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			for _, nonceKey := range expired {
+				if err := bucket.Delete(nonceKey); err != nil {
+					return err
+				}
+			}
+		}
+
+		nonceKey := []byte(key + "\x00" + nonce)
+		if used := bucket.Get(nonceKey); used != nil && !nonceExpiry(used).Before(now) {
+			return ErrNonceUsed
+		}
+		value := make([]byte, 8)
+		binary.BigEndian.PutUint64(value, uint64(expires.UnixNano()))
+		return bucket.Put(nonceKey, value)
+	})
+}
+
+// nonceExpiry - Expiry time stored in nonceBucket
+func nonceExpiry(value []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(value)))
+}
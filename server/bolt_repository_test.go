@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// setupBolt - Opens a bolt file in a new temporary directory, removed by clearBolt
+func setupBolt(t *testing.T) *boltRepository {
+	dir, err := ioutil.TempDir("", "upvotesystem-bolt")
+	require.Nil(t, err)
+
+	repository, err := openBoltRepository(filepath.Join(dir, "UpvoteSystemTest.db"))
+	require.Nil(t, err)
+
+	return repository
+}
+
+func clearBolt(repository *boltRepository) {
+	repository.Close()
+	os.RemoveAll(filepath.Dir(repository.path))
+}
+
+// reopenBolt - Closes repository and opens its file again
+func reopenBolt(t *testing.T, repository *boltRepository) *boltRepository {
+	require.Nil(t, repository.Close())
+
+	reopened, err := openBoltRepository(repository.path)
+	require.Nil(t, err)
+
+	return reopened
+}
+
+func TestBoltUniqueName(t *testing.T) {
+	repository := setupBolt(t)
+	defer clearBolt(repository)
+	ctx := context.Background()
+
+	bitcoin, err := repository.Create(ctx, model.Crypto{Name: "Bitcoin", Description: "The most valuable cryptocurrency", Symbol: "BTC"})
+	require.Nil(t, err)
+
+	// Test names and symbols are unique ignoring case
+	_, err = repository.Create(ctx, model.Crypto{Name: "BITCOIN", Description: "Copy"})
+	assert.Equal(t, ErrAlreadyExists, err)
+
+	_, err = repository.Create(ctx, model.Crypto{Name: "Bitcoin Cash", Description: "Fork", Symbol: "btc"})
+	assert.Equal(t, ErrAlreadyExists, err)
+
+	found, err := repository.GetBySymbol(ctx, "bTc")
+	require.Nil(t, err)
+	assert.Equal(t, bitcoin.ID, found.ID)
+
+	// Test renaming releases the previous name
+	bitcoin.Name = "Bitcoin Core"
+	_, err = repository.Update(ctx, bitcoin)
+	require.Nil(t, err)
+
+	_, err = repository.Create(ctx, model.Crypto{Name: "bitcoin", Description: "Reused name"})
+	assert.Nil(t, err)
+}
+
+func TestBoltUpdateVote(t *testing.T) {
+	repository := setupBolt(t)
+	defer clearBolt(repository)
+	ctx := context.Background()
+
+	crypto, err := repository.Create(ctx, model.Crypto{Name: "Bitcoin", Description: "The most valuable cryptocurrency"})
+	require.Nil(t, err)
+
+	upvote := func(model.VoteDirection) (model.VoteDirection, error) { return model.Upvote, nil }
+	downvote := func(model.VoteDirection) (model.VoteDirection, error) { return model.Downvote, nil }
+
+	// Concurrent votes of different voters must not lose updates
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(voter int) {
+			defer wg.Done()
+			_, err := repository.UpdateVote(ctx, crypto.ID, fmt.Sprintf("voter-%d", voter), upvote)
+			assert.Nil(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	// Test a rejected decision leaves the vote and the counters untouched
+	onlyOnce := func(current model.VoteDirection) (model.VoteDirection, error) {
+		if current == model.Upvote {
+			return current, errDuplicateVote
+		}
+		return model.Upvote, nil
+	}
+	_, err = repository.UpdateVote(ctx, crypto.ID, "voter-0", onlyOnce)
+	assert.Equal(t, errDuplicateVote, err)
+
+	crypto, err = repository.UpdateVote(ctx, crypto.ID, "voter-1", downvote)
+	require.Nil(t, err)
+
+	assert.Equal(t, int32(19), crypto.Upvote)
+	assert.Equal(t, int32(1), crypto.Downvote)
+
+	// Test deleting the cryptocurrency deletes its votes
+	require.Nil(t, repository.Delete(ctx, crypto.ID))
+
+	err = repository.db.View(func(tx *bolt.Tx) error {
+		key, _ := tx.Bucket(voteBucket).Cursor().Seek(crypto.ID[:])
+		assert.False(t, bytes.HasPrefix(key, crypto.ID[:]))
+		return nil
+	})
+	require.Nil(t, err)
+
+	_, err = repository.UpdateVote(ctx, crypto.ID, "voter-0", upvote)
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestBoltPersistence(t *testing.T) {
+	repository := setupBolt(t)
+	defer func() { clearBolt(repository) }()
+	ctx := context.Background()
+
+	bitcoin, err := repository.Create(ctx, model.Crypto{Name: "Bitcoin", Description: "The most valuable cryptocurrency", Symbol: "BTC"})
+	require.Nil(t, err)
+
+	upvote := func(current model.VoteDirection) (model.VoteDirection, error) {
+		if current == model.Upvote {
+			return current, errDuplicateVote
+		}
+		return model.Upvote, nil
+	}
+	_, err = repository.UpdateVote(ctx, bitcoin.ID, "alice", upvote)
+	require.Nil(t, err)
+
+	repository = reopenBolt(t, repository)
+
+	// Test cryptocurrencies, votes and unique names survive closing the file
+	found, err := repository.GetByID(ctx, bitcoin.ID)
+	require.Nil(t, err)
+	assert.Equal(t, "Bitcoin", found.Name)
+	assert.Equal(t, int32(1), found.Upvote)
+
+	_, err = repository.UpdateVote(ctx, bitcoin.ID, "alice", upvote)
+	assert.Equal(t, errDuplicateVote, err)
+
+	_, err = repository.Create(ctx, model.Crypto{Name: "bitcoin", Description: "Copy"})
+	assert.Equal(t, ErrAlreadyExists, err)
+
+	found, err = repository.GetBySymbol(ctx, "btc")
+	require.Nil(t, err)
+	assert.Equal(t, bitcoin.ID, found.ID)
+}
+
+func TestBoltCaseConflicts(t *testing.T) {
+	repository := setupBolt(t)
+	defer func() { clearBolt(repository) }()
+	ctx := context.Background()
+
+	// Files written before names were unique ignoring case hold conflicting names
+	older := model.Crypto{ID: primitive.NewObjectID(), Name: "bitcoin", Description: "First"}
+	newer := model.Crypto{ID: primitive.NewObjectID(), Name: "Bitcoin", Description: "Second"}
+	err := repository.db.Update(func(tx *bolt.Tx) error {
+		if err := putCrypto(tx, older); err != nil {
+			return err
+		}
+		return putCrypto(tx, newer)
+	})
+	require.Nil(t, err)
+
+	// Test opening the file renames the newer one instead of failing
+	repository = reopenBolt(t, repository)
+
+	found, err := repository.GetByID(ctx, older.ID)
+	require.Nil(t, err)
+	assert.Equal(t, "bitcoin", found.Name)
+
+	found, err = repository.GetByID(ctx, newer.ID)
+	require.Nil(t, err)
+	assert.Equal(t, "Bitcoin ("+newer.ID.Hex()+")", found.Name)
+
+	_, err = repository.Create(ctx, model.Crypto{Name: "BITCOIN", Description: "Copy"})
+	assert.Equal(t, ErrAlreadyExists, err)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	upvoteSystem "github.com/RomuloSiebra/CryptoUpvoteSystem/proto/UpvoteSystem"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxTags - Most tags of a cryptocurrency
+	maxTags = 20
+	// maxTagLength - Longest tag, in runes
+	maxTagLength = 32
+)
+
+// symbolPattern - Ticker symbols, letters and digits optionally followed by dots or dashes
+var symbolPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9.-]{0,11}$`)
+
+// cryptoDetails - Validates the name, description and metadata of crypto and returns them normalised,
+// the symbol in upper case and the tags in lower case without duplicates
+func cryptoDetails(crypto *upvoteSystem.Cryptocurrency) (model.Crypto, error) {
+	details := model.Crypto{
+		Name:        crypto.GetName(),
+		Description: crypto.GetDescription(),
+		Symbol:      strings.ToUpper(strings.TrimSpace(crypto.GetSymbol())),
+		Website:     strings.TrimSpace(crypto.GetWebsite()),
+		LogoURL:     strings.TrimSpace(crypto.GetLogoUrl()),
+	}
+
+	if details.Name == "" || details.Description == "" {
+		return model.Crypto{}, status.Errorf(codes.InvalidArgument, "Empty fields")
+	}
+	if details.Symbol != "" && !symbolPattern.MatchString(details.Symbol) {
+		return model.Crypto{}, status.Errorf(codes.InvalidArgument, "Invalid symbol")
+	}
+	if details.Website != "" && !isWebURL(details.Website) {
+		return model.Crypto{}, status.Errorf(codes.InvalidArgument, "Invalid website")
+	}
+	if details.LogoURL != "" && !isWebURL(details.LogoURL) {
+		return model.Crypto{}, status.Errorf(codes.InvalidArgument, "Invalid logo URL")
+	}
+
+	if crypto.GetLaunchDate() != nil {
+		if err := crypto.GetLaunchDate().CheckValid(); err != nil {
+			return model.Crypto{}, status.Errorf(codes.InvalidArgument, "Invalid launch date")
+		}
+		details.LaunchDate = crypto.GetLaunchDate().AsTime()
+	}
+
+	seen := make(map[string]bool)
+	for _, tag := range crypto.GetTags() {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || utf8.RuneCountInString(tag) > maxTagLength {
+			return model.Crypto{}, status.Errorf(codes.InvalidArgument, "Invalid tag")
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			details.Tags = append(details.Tags, tag)
+		}
+	}
+	if len(details.Tags) > maxTags {
+		return model.Crypto{}, status.Errorf(codes.InvalidArgument, "Too many tags")
+	}
+	return details, nil
+}
+
+// isWebURL - Reports whether value is an absolute http or https URL
+func isWebURL(value string) bool {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+)
+
+// Event source names of the EVENT_SOURCE environment variable
+const (
+	localEventSourceName = "local"
+	mongoEventSourceName = "mongo"
+)
+
+// EventSource - Delivers the cryptocurrency changes to the stream hub
+type EventSource interface {
+	// Publish reports a change stored by this server
+	Publish(kind eventType, crypto model.Crypto)
+	// Run feeds the hub until ctx is done
+	Run(ctx context.Context) error
+}
+
+// localEventSource - Publishes the changes of this server only, streams miss the changes
+// stored by other replicas
+type localEventSource struct {
+	hub *hub
+}
+
+func (s localEventSource) Publish(kind eventType, crypto model.Crypto) {
+	s.hub.publish(kind, crypto)
+}
+
+func (s localEventSource) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+// openEventSource - Returns the named event source feeding h, the mongo source requires the mongo storage backend
+func openEventSource(name string, storage Storage, h *hub, replicaID string) (EventSource, error) {
+	switch name {
+	case localEventSourceName:
+		return localEventSource{hub: h}, nil
+	case mongoEventSourceName:
+		repository, ok := storage.(*mongoRepository)
+		if !ok {
+			return nil, fmt.Errorf("the %s event source requires the %s storage backend", mongoEventSourceName, mongoBackend)
+		}
+		return newMongoEventSource(repository, h, replicaID), nil
+	}
+	return nil, fmt.Errorf("unknown event source %q", name)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	upvoteSystem "github.com/RomuloSiebra/CryptoUpvoteSystem/proto/UpvoteSystem"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *server) GetVoteHistory(ctx context.Context, request *upvoteSystem.GetVoteHistoryRequest) (*upvoteSystem.GetVoteHistoryResponse, error) {
+	cryptoID, err := primitive.ObjectIDFromHex(request.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, fmt.Sprintf("%v", err))
+	}
+
+	from := time.Unix(0, 0).UTC()
+	if request.GetFrom() != nil {
+		from = request.GetFrom().AsTime()
+	}
+
+	to := time.Now().UTC()
+	if request.GetTo() != nil {
+		to = request.GetTo().AsTime()
+	}
+
+	if to.Before(from) {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid time range")
+	}
+
+	bucket, found := historyBuckets[request.GetResolution()]
+	if !found {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid resolution")
+	}
+
+	snapshots, err := s.snapshots.ListSnapshots(ctx, cryptoID, from, to)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, fmt.Sprintf("Error: %v", err))
+	}
+
+	response := &upvoteSystem.GetVoteHistoryResponse{}
+	for _, snapshot := range downsample(snapshots, bucket) {
+		response.Snapshots = append(response.Snapshots, &upvoteSystem.VoteSnapshot{
+			Timestamp: timestamppb.New(snapshot.Timestamp),
+			Upvote:    snapshot.Upvote,
+			Downvote:  snapshot.Downvote,
+			Sum:       snapshot.Sum,
+		})
+	}
+	return response, nil
+}
+
+// historyBuckets - Bucket width of each resolution, zero keeps every snapshot
+var historyBuckets = map[upvoteSystem.HistoryResolution]time.Duration{
+	upvoteSystem.HistoryResolution_RESOLUTION_RAW:    0,
+	upvoteSystem.HistoryResolution_RESOLUTION_HOURLY: time.Hour,
+	upvoteSystem.HistoryResolution_RESOLUTION_DAILY:  24 * time.Hour,
+}
+
+// downsample - Keeps the last snapshot of each UTC bucket, stamped with the bucket start.
+// Counters only grow or shrink with votes, so the last value is the bucket closing state
+func downsample(snapshots []model.Snapshot, bucket time.Duration) []model.Snapshot {
+	if bucket == 0 {
+		return snapshots
+	}
+
+	var result []model.Snapshot
+	for _, snapshot := range snapshots {
+		snapshot.Timestamp = snapshot.Timestamp.UTC().Truncate(bucket)
+
+		if last := len(result) - 1; last >= 0 && result[last].Timestamp.Equal(snapshot.Timestamp) {
+			result[last] = snapshot
+			continue
+		}
+		result = append(result, snapshot)
+	}
+	return result
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// defaultStreamBufferSize - Updates queued per stream subscriber while it is busy sending
+const defaultStreamBufferSize = 16
+
+// defaultReplayBufferSize - Latest events retained per cryptocurrency for resuming streams
+const defaultReplayBufferSize = 128
+
+// slowConsumerPolicy - What the hub does when a subscriber buffer is full
+type slowConsumerPolicy int
+
+const (
+	// dropOldest - Discards the oldest queued update to make room for the new one
+	dropOldest slowConsumerPolicy = iota
+	// coalesceLatest - Replaces every queued update with the new one, subscribers only need the latest counters
+	coalesceLatest
+	// disconnectSlow - Drops the subscription, its stream ends with ResourceExhausted
+	disconnectSlow
+)
+
+var slowConsumerPolicyNames = map[string]slowConsumerPolicy{
+	"drop-oldest": dropOldest,
+	"coalesce":    coalesceLatest,
+	"disconnect":  disconnectSlow,
+}
+
+// parseSlowConsumerPolicy - Parses the SLOW_CONSUMER_POLICY environment variable value
+func parseSlowConsumerPolicy(name string) (slowConsumerPolicy, error) {
+	policy, found := slowConsumerPolicyNames[strings.ToLower(name)]
+	if !found {
+		return 0, fmt.Errorf("unknown slow consumer policy %q", name)
+	}
+	return policy, nil
+}
+
+// eventType - Kind of change of a cryptocurrency
+type eventType int
+
+const (
+	eventCreated eventType = iota + 1
+	eventUpdated
+	eventDeleted
+	eventVoted
+)
+
+// event - Change of a cryptocurrency numbered in publish order per cryptocurrency
+type event struct {
+	kind      eventType
+	sequence  uint64
+	timestamp time.Time
+	crypto    model.Crypto
+}
+
+// hub - Fans cryptocurrency changes out to the stream subscribers of each cryptocurrency
+// and to the subscribers of every cryptocurrency. Sequences only exist in this process, epoch
+// tells them apart from the ones of a previous or another server
+type hub struct {
+	epoch      uint64
+	mutex      sync.RWMutex
+	topics     map[primitive.ObjectID]map[*subscription]struct{}
+	wildcard   map[*subscription]struct{}
+	sequences  map[primitive.ObjectID]uint64
+	history    map[primitive.ObjectID][]event
+	bufferSize int
+	replaySize int
+	policy     slowConsumerPolicy
+	now        func() time.Time
+}
+
+// subscription - Events of the subscribed topics, or of every topic when topics is empty.
+// Dropped is closed when the hub disconnects a slow subscriber
+type subscription struct {
+	topics  []primitive.ObjectID
+	updates chan event
+	dropped chan struct{}
+}
+
+func newHub(bufferSize int, policy slowConsumerPolicy) *hub {
+	if bufferSize < 1 {
+		bufferSize = 1
+	}
+	return &hub{
+		epoch:      newEpoch(),
+		topics:     make(map[primitive.ObjectID]map[*subscription]struct{}),
+		wildcard:   make(map[*subscription]struct{}),
+		sequences:  make(map[primitive.ObjectID]uint64),
+		history:    make(map[primitive.ObjectID][]event),
+		bufferSize: bufferSize,
+		replaySize: defaultReplayBufferSize,
+		policy:     policy,
+		now:        time.Now,
+	}
+}
+
+// newEpoch - Random non-zero epoch, zero is the unset resume epoch of requests
+func newEpoch() uint64 {
+	var b [8]byte
+	for {
+		if _, err := rand.Read(b[:]); err != nil {
+			panic(err)
+		}
+		if epoch := binary.BigEndian.Uint64(b[:]); epoch != 0 {
+			return epoch
+		}
+	}
+}
+
+// subscribe - Registers a subscriber for the events of topics, or of every topic when none is
+// given, and returns the sequence of the last event published on each topic before it.
+// Callers must unsubscribe it when done
+func (h *hub) subscribe(topics ...primitive.ObjectID) (*subscription, []uint64) {
+	sub := h.newSubscription(topics)
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.add(sub)
+
+	if len(topics) == 0 {
+		return sub, nil
+	}
+	sequences := make([]uint64, len(topics))
+	for i, topic := range topics {
+		sequences[i] = h.sequences[topic]
+	}
+	return sub, sequences
+}
+
+// resume - Registers a subscriber for topic like subscribe and returns the retained events
+// published after the given sequence. Resumed is false when after is zero, ahead of the topic
+// or older than the retained events, the subscriber needs the current state at sequence then
+func (h *hub) resume(topic primitive.ObjectID, after uint64) (sub *subscription, missed []event, sequence uint64, resumed bool) {
+	sub = h.newSubscription([]primitive.ObjectID{topic})
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.add(sub)
+
+	sequence = h.sequences[topic]
+	if after == 0 || after > sequence {
+		return sub, nil, sequence, false
+	}
+
+	history := h.history[topic]
+	count := int(sequence - after)
+	if count > len(history) {
+		return sub, nil, sequence, false
+	}
+	missed = append(missed, history[len(history)-count:]...)
+	return sub, missed, sequence, true
+}
+
+func (h *hub) newSubscription(topics []primitive.ObjectID) *subscription {
+	return &subscription{
+		topics:  topics,
+		updates: make(chan event, h.bufferSize),
+		dropped: make(chan struct{}),
+	}
+}
+
+// add - Registers the subscriber on its topics, the hub lock must be held
+func (h *hub) add(sub *subscription) {
+	if len(sub.topics) == 0 {
+		h.wildcard[sub] = struct{}{}
+		return
+	}
+
+	for _, topic := range sub.topics {
+		subscribers, found := h.topics[topic]
+		if !found {
+			subscribers = make(map[*subscription]struct{})
+			h.topics[topic] = subscribers
+		}
+		subscribers[sub] = struct{}{}
+	}
+}
+
+// unsubscribe - Removes the subscriber, it is safe to call more than once
+func (h *hub) unsubscribe(sub *subscription) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.remove(sub)
+}
+
+// remove - Deletes the subscriber and its topics once empty, the hub lock must be held
+func (h *hub) remove(sub *subscription) {
+	delete(h.wildcard, sub)
+	for _, topic := range sub.topics {
+		subscribers := h.topics[topic]
+		delete(subscribers, sub)
+		if len(subscribers) == 0 {
+			delete(h.topics, topic)
+		}
+	}
+}
+
+// subscribers - Number of subscribers of topic, subscribers of every topic excluded
+func (h *hub) subscribers(topic primitive.ObjectID) int {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	return len(h.topics[topic])
+}
+
+// publish - Numbers and retains the change and queues it for every subscriber of its
+// cryptocurrency without blocking
+func (h *hub) publish(kind eventType, crypto model.Crypto) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.sequences[crypto.ID]++
+	e := event{kind: kind, sequence: h.sequences[crypto.ID], timestamp: h.now(), crypto: crypto}
+
+	if kind == eventDeleted {
+		delete(h.sequences, crypto.ID)
+		delete(h.history, crypto.ID)
+	} else if h.replaySize > 0 {
+		history := append(h.history[crypto.ID], e)
+		if len(history) > h.replaySize {
+			history = history[len(history)-h.replaySize:]
+		}
+		h.history[crypto.ID] = history
+	}
+
+	h.deliver(h.topics[crypto.ID], e)
+	h.deliver(h.wildcard, e)
+}
+
+// deliver - Offers the event to subscribers and disconnects the slow ones, the hub lock must be held
+func (h *hub) deliver(subscribers map[*subscription]struct{}, e event) {
+	for sub := range subscribers {
+		if !sub.offer(e, h.policy) {
+			h.remove(sub)
+			close(sub.dropped)
+		}
+	}
+}
+
+// offer - Queues the event applying policy when the buffer is full, returns false
+// when the subscriber must be disconnected
+func (sub *subscription) offer(e event, policy slowConsumerPolicy) bool {
+	for {
+		select {
+		case sub.updates <- e:
+			return true
+		default:
+		}
+
+		switch policy {
+		case disconnectSlow:
+			return false
+		case coalesceLatest:
+			sub.coalesce(e.crypto.ID)
+		default:
+			sub.dropOldest()
+		}
+	}
+}
+
+// coalesce - Discards the queued events of the cryptocurrency, the new event carries its
+// latest state. Drops the oldest event when none of them is about the cryptocurrency
+func (sub *subscription) coalesce(id primitive.ObjectID) {
+	var kept []event
+	for len(sub.updates) > 0 {
+		select {
+		case queued := <-sub.updates:
+			if queued.crypto.ID != id {
+				kept = append(kept, queued)
+			}
+		default:
+		}
+	}
+
+	if len(kept) == cap(sub.updates) {
+		kept = kept[1:]
+	}
+	for _, queued := range kept {
+		sub.updates <- queued
+	}
+}
+
+// dropOldest - Discards the oldest queued event
+func (sub *subscription) dropOldest() {
+	select {
+	case <-sub.updates:
+	default:
+	}
+}
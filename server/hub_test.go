@@ -0,0 +1,262 @@
+package main
+
+import (
+	"sync"
+	"testing"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func queued(sub *subscription) []int32 {
+	var upvotes []int32
+	for {
+		select {
+		case update := <-sub.updates:
+			upvotes = append(upvotes, update.crypto.Upvote)
+		default:
+			return upvotes
+		}
+	}
+}
+
+func TestHubTopics(t *testing.T) {
+	h := newHub(4, dropOldest)
+	bitcoin := primitive.NewObjectID()
+	ethereum := primitive.NewObjectID()
+
+	bitcoinSub, _ := h.subscribe(bitcoin)
+	ethereumSub, _ := h.subscribe(ethereum)
+
+	h.publish(eventVoted, model.Crypto{ID: bitcoin, Upvote: 1})
+
+	assert.Equal(t, []int32{1}, queued(bitcoinSub))
+	assert.Nil(t, queued(ethereumSub))
+
+	// Test unsubscribe removes empty topics and can be repeated
+	h.unsubscribe(bitcoinSub)
+	h.unsubscribe(bitcoinSub)
+
+	assert.Equal(t, 0, h.subscribers(bitcoin))
+	assert.Equal(t, 1, h.subscribers(ethereum))
+	assert.NotContains(t, h.topics, bitcoin)
+
+	h.publish(eventVoted, model.Crypto{ID: bitcoin, Upvote: 2})
+
+	assert.Nil(t, queued(bitcoinSub))
+}
+
+func TestHubSequences(t *testing.T) {
+	h := newHub(4, dropOldest)
+	bitcoin := primitive.NewObjectID()
+	ethereum := primitive.NewObjectID()
+
+	// Test sequences count per topic even without subscribers
+	h.publish(eventVoted, model.Crypto{ID: bitcoin})
+	h.publish(eventVoted, model.Crypto{ID: bitcoin})
+
+	sub, sequences := h.subscribe(bitcoin)
+
+	assert.Equal(t, []uint64{2}, sequences)
+
+	h.publish(eventVoted, model.Crypto{ID: ethereum})
+	h.publish(eventVoted, model.Crypto{ID: bitcoin})
+
+	e := <-sub.updates
+
+	assert.Equal(t, uint64(3), e.sequence)
+	assert.False(t, e.timestamp.IsZero())
+
+	_, sequences = h.subscribe(ethereum, bitcoin)
+
+	assert.Equal(t, []uint64{1, 3}, sequences)
+
+	// Test deleting a cryptocurrency forgets its sequence
+	h.publish(eventDeleted, model.Crypto{ID: bitcoin})
+
+	e = <-sub.updates
+
+	assert.Equal(t, eventDeleted, e.kind)
+	assert.Equal(t, uint64(4), e.sequence)
+
+	_, sequences = h.subscribe(bitcoin)
+
+	assert.Equal(t, []uint64{0}, sequences)
+}
+
+func TestHubResume(t *testing.T) {
+	h := newHub(4, dropOldest)
+	h.replaySize = 3
+	id := primitive.NewObjectID()
+
+	for i := int32(1); i <= 5; i++ {
+		h.publish(eventVoted, model.Crypto{ID: id, Upvote: i})
+	}
+
+	// Test resuming within the retained events returns the missed ones
+	sub, missed, sequence, resumed := h.resume(id, 3)
+
+	assert.True(t, resumed)
+	assert.Equal(t, uint64(5), sequence)
+	require.Len(t, missed, 2)
+	assert.Equal(t, uint64(4), missed[0].sequence)
+	assert.Equal(t, int32(5), missed[1].crypto.Upvote)
+
+	// Test the subscription continues after the missed events
+	h.publish(eventVoted, model.Crypto{ID: id, Upvote: 6})
+
+	assert.Equal(t, []int32{6}, queued(sub))
+
+	// Test resuming from the latest sequence misses nothing
+	_, missed, _, resumed = h.resume(id, 6)
+
+	assert.True(t, resumed)
+	assert.Empty(t, missed)
+
+	// Test resuming before the retained events, ahead of the topic or from zero needs a snapshot
+	for _, after := range []uint64{2, 7, 0} {
+		_, missed, sequence, resumed = h.resume(id, after)
+
+		assert.False(t, resumed)
+		assert.Empty(t, missed)
+		assert.Equal(t, uint64(6), sequence)
+	}
+
+	// Test deleting forgets the retained events
+	h.publish(eventDeleted, model.Crypto{ID: id})
+
+	_, _, sequence, resumed = h.resume(id, 6)
+
+	assert.False(t, resumed)
+	assert.Equal(t, uint64(0), sequence)
+	assert.NotContains(t, h.history, id)
+}
+
+func TestHubWildcard(t *testing.T) {
+	h := newHub(4, dropOldest)
+	bitcoin := primitive.NewObjectID()
+	ethereum := primitive.NewObjectID()
+	litecoin := primitive.NewObjectID()
+
+	all, sequences := h.subscribe()
+
+	assert.Nil(t, sequences)
+
+	some, _ := h.subscribe(bitcoin, ethereum)
+
+	h.publish(eventCreated, model.Crypto{ID: bitcoin, Upvote: 1})
+	h.publish(eventCreated, model.Crypto{ID: ethereum, Upvote: 2})
+	h.publish(eventCreated, model.Crypto{ID: litecoin, Upvote: 3})
+
+	assert.Equal(t, []int32{1, 2, 3}, queued(all))
+	assert.Equal(t, []int32{1, 2}, queued(some))
+
+	// Test unsubscribe removes the subscriber from every topic
+	h.unsubscribe(all)
+	h.unsubscribe(some)
+
+	assert.Empty(t, h.topics)
+	assert.Empty(t, h.wildcard)
+}
+
+func TestHubSlowConsumerPolicies(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	// Test drop oldest keeps the newest updates
+	h := newHub(2, dropOldest)
+	sub, _ := h.subscribe(id)
+	for i := int32(1); i <= 4; i++ {
+		h.publish(eventVoted, model.Crypto{ID: id, Upvote: i})
+	}
+
+	assert.Equal(t, []int32{3, 4}, queued(sub))
+
+	// Test coalesce replaces the queued updates with the latest one on overflow
+	h = newHub(2, coalesceLatest)
+	sub, _ = h.subscribe(id)
+	for i := int32(1); i <= 5; i++ {
+		h.publish(eventVoted, model.Crypto{ID: id, Upvote: i})
+	}
+
+	assert.Equal(t, []int32{5}, queued(sub))
+
+	// Test coalesce keeps the queued events of other cryptocurrencies
+	other := primitive.NewObjectID()
+	h = newHub(3, coalesceLatest)
+	sub, _ = h.subscribe()
+	h.publish(eventVoted, model.Crypto{ID: id, Upvote: 1})
+	h.publish(eventVoted, model.Crypto{ID: other, Upvote: 2})
+	h.publish(eventVoted, model.Crypto{ID: id, Upvote: 3})
+	h.publish(eventVoted, model.Crypto{ID: id, Upvote: 4})
+
+	assert.Equal(t, []int32{2, 4}, queued(sub))
+
+	// Test coalesce drops the oldest event when none is about the cryptocurrency
+	h.publish(eventVoted, model.Crypto{ID: other, Upvote: 5})
+	h.publish(eventVoted, model.Crypto{ID: other, Upvote: 6})
+	h.publish(eventVoted, model.Crypto{ID: other, Upvote: 7})
+	h.publish(eventVoted, model.Crypto{ID: id, Upvote: 8})
+
+	assert.Equal(t, []int32{6, 7, 8}, queued(sub))
+
+	// Test disconnect drops the subscriber once its buffer is full
+	h = newHub(2, disconnectSlow)
+	sub, _ = h.subscribe(id)
+	for i := int32(1); i <= 3; i++ {
+		h.publish(eventVoted, model.Crypto{ID: id, Upvote: i})
+	}
+
+	select {
+	case <-sub.dropped:
+	default:
+		require.Fail(t, "slow subscriber was not disconnected")
+	}
+	assert.Equal(t, 0, h.subscribers(id))
+	assert.Equal(t, []int32{1, 2}, queued(sub))
+
+	// Unsubscribing a disconnected subscriber is a no-op
+	h.unsubscribe(sub)
+}
+
+func TestHubConcurrentSubscribers(t *testing.T) {
+	h := newHub(defaultStreamBufferSize, coalesceLatest)
+	id := primitive.NewObjectID()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			sub, _ := h.subscribe(id)
+			h.publish(eventVoted, model.Crypto{ID: id, Upvote: int32(i)})
+			<-sub.updates
+			h.unsubscribe(sub)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 0, h.subscribers(id))
+}
+
+func TestParseSlowConsumerPolicy(t *testing.T) {
+	policy, err := parseSlowConsumerPolicy("Disconnect")
+
+	require.Nil(t, err)
+	assert.Equal(t, disconnectSlow, policy)
+
+	_, err = parseSlowConsumerPolicy("block")
+
+	assert.NotNil(t, err)
+}
+
+func TestHubEpoch(t *testing.T) {
+	// Test every hub numbers its sequences in a new non-zero epoch
+	first := newHub(4, dropOldest)
+	second := newHub(4, dropOldest)
+
+	assert.NotZero(t, first.epoch)
+	assert.NotEqual(t, first.epoch, second.epoch)
+}
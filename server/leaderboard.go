@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	upvoteSystem "github.com/RomuloSiebra/CryptoUpvoteSystem/proto/UpvoteSystem"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// defaultLeaderboardSize - Number of ranked cryptocurrencies when the request limit is unset
+	defaultLeaderboardSize = 10
+	// maxLeaderboardSize - Largest leaderboard, bigger limits are rejected
+	maxLeaderboardSize = 100
+)
+
+// leaderboardMetrics - SortField ranking each proto metric, best scores first
+var leaderboardMetrics = map[upvoteSystem.LeaderboardMetric]SortField{
+	upvoteSystem.LeaderboardMetric_METRIC_NET_VOTES:     SortByNetVotes,
+	upvoteSystem.LeaderboardMetric_METRIC_UPVOTES:       SortByUpvote,
+	upvoteSystem.LeaderboardMetric_METRIC_DOWNVOTES:     SortByDownvote,
+	upvoteSystem.LeaderboardMetric_METRIC_WILSON:        SortByWilson,
+	upvoteSystem.LeaderboardMetric_METRIC_HOT:           SortByHot,
+	upvoteSystem.LeaderboardMetric_METRIC_CONTROVERSIAL: SortByControversial,
+}
+
+// leaderboardQuery - Builds the ListQuery of the top cryptocurrencies of a leaderboard request
+func leaderboardQuery(request *upvoteSystem.GetLeaderboardRequest) (ListQuery, error) {
+	orderBy, found := leaderboardMetrics[request.GetMetric()]
+	if !found {
+		return ListQuery{}, status.Errorf(codes.InvalidArgument, "Invalid metric")
+	}
+
+	limit := int(request.GetLimit())
+	if limit < 0 || limit > maxLeaderboardSize {
+		return ListQuery{}, status.Errorf(codes.InvalidArgument, "Invalid limit")
+	}
+	if limit == 0 {
+		limit = defaultLeaderboardSize
+	}
+	return ListQuery{OrderBy: orderBy, Descending: true, Limit: limit}, nil
+}
+
+// leaderboardScore - Value of the ranking field of crypto
+func leaderboardScore(field SortField, crypto model.Crypto) float64 {
+	if ranking, computed := rankings[field]; computed {
+		return ranking.Score(crypto)
+	}
+
+	switch field {
+	case SortByUpvote:
+		return float64(crypto.Upvote)
+	case SortByDownvote:
+		return float64(crypto.Downvote)
+	}
+	return float64(crypto.Upvote) - float64(crypto.Downvote)
+}
+
+// leaderboard - Ranks the cryptocurrencies listed by query, equal scores share a rank
+func (s *server) leaderboard(ctx context.Context, query ListQuery) ([]*upvoteSystem.LeaderboardEntry, error) {
+	cryptos, err := s.repository.List(ctx, query)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+
+	entries := make([]*upvoteSystem.LeaderboardEntry, len(cryptos))
+	for i, crypto := range cryptos {
+		entry := &upvoteSystem.LeaderboardEntry{
+			Rank:   uint32(i + 1),
+			Crypto: toCryptocurrency(crypto),
+			Score:  leaderboardScore(query.OrderBy, crypto),
+		}
+		if i > 0 && entries[i-1].Score == entry.Score {
+			entry.Rank = entries[i-1].Rank
+		}
+		entries[i] = entry
+	}
+	return entries, nil
+}
+
+// rankChanges - Cryptocurrencies whose rank differs between two boards, in the order of next
+// followed by the ones that left it
+func rankChanges(previous []*upvoteSystem.LeaderboardEntry, next []*upvoteSystem.LeaderboardEntry) []*upvoteSystem.RankChange {
+	previousRanks := make(map[string]uint32, len(previous))
+	for _, entry := range previous {
+		previousRanks[entry.GetCrypto().GetId()] = entry.GetRank()
+	}
+
+	var changes []*upvoteSystem.RankChange
+	for _, entry := range next {
+		id := entry.GetCrypto().GetId()
+		if previousRank := previousRanks[id]; previousRank != entry.GetRank() {
+			changes = append(changes, &upvoteSystem.RankChange{Id: id, PreviousRank: previousRank, Rank: entry.GetRank()})
+		}
+		delete(previousRanks, id)
+	}
+	for _, entry := range previous {
+		id := entry.GetCrypto().GetId()
+		if previousRank, found := previousRanks[id]; found {
+			changes = append(changes, &upvoteSystem.RankChange{Id: id, PreviousRank: previousRank})
+		}
+	}
+	return changes
+}
+
+func (s *server) GetLeaderboard(ctx context.Context, request *upvoteSystem.GetLeaderboardRequest) (*upvoteSystem.GetLeaderboardResponse, error) {
+	query, err := leaderboardQuery(request)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.leaderboard(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return &upvoteSystem.GetLeaderboardResponse{Entries: entries}, nil
+}
+
+// WatchLeaderboard sends the board, then the boards ranked again by the shared ranker after votes,
+// creations and deletions with the rank changes whenever they reorder it
+func (s *server) WatchLeaderboard(request *upvoteSystem.GetLeaderboardRequest, stream upvoteSystem.UpvoteSystem_WatchLeaderboardServer) error {
+	query, err := leaderboardQuery(request)
+	if err != nil {
+		return err
+	}
+
+	watcher, board, err := s.leaderboards.watch(stream.Context(), query.OrderBy)
+	if err != nil {
+		return err
+	}
+	defer s.leaderboards.unwatch(watcher)
+
+	current := topEntries(board, query.Limit)
+	if err := stream.Send(&upvoteSystem.WatchLeaderboardResponse{Entries: current, Timestamp: timestamppb.Now()}); err != nil {
+		return nil
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case err := <-watcher.failed:
+			return err
+		case board := <-watcher.boards:
+			next := topEntries(board, query.Limit)
+			changes := rankChanges(current, next)
+			current = next
+			if len(changes) == 0 {
+				continue
+			}
+
+			response := &upvoteSystem.WatchLeaderboardResponse{
+				Entries:   next,
+				Changes:   changes,
+				Timestamp: timestamppb.Now(),
+			}
+			if err := stream.Send(response); err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+// drainUpdates - Discards the events already queued for sub, a single ranking covers them all
+func drainUpdates(sub *subscription) {
+	for {
+		select {
+		case <-sub.updates:
+		default:
+			return
+		}
+	}
+}
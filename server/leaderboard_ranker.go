@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"sync"
+
+	upvoteSystem "github.com/RomuloSiebra/CryptoUpvoteSystem/proto/UpvoteSystem"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// leaderboardRanker - Ranks the boards watched by WatchLeaderboard once per batch of events and
+// shares them between the watchers. Each metric is ranked at maxLeaderboardSize and watchers keep
+// the top of their limit, so the store is listed once per watched metric and not once per watcher
+type leaderboardRanker struct {
+	hub  *hub
+	rank func(ctx context.Context, query ListQuery) ([]*upvoteSystem.LeaderboardEntry, error)
+
+	mu     sync.Mutex
+	boards map[SortField]*sharedBoard
+}
+
+// sharedBoard - Latest board of a metric and its watchers, ranked while it has any
+type sharedBoard struct {
+	field    SortField
+	current  []*upvoteSystem.LeaderboardEntry
+	watchers map[*boardWatcher]struct{}
+	stop     context.CancelFunc
+}
+
+// boardWatcher - Latest board not yet taken by a watcher, and the error ending the watch.
+// Boards are coalesced, watchers only need the latest one
+type boardWatcher struct {
+	board  *sharedBoard
+	boards chan []*upvoteSystem.LeaderboardEntry
+	failed chan error
+}
+
+func newLeaderboardRanker(h *hub, rank func(ctx context.Context, query ListQuery) ([]*upvoteSystem.LeaderboardEntry, error)) *leaderboardRanker {
+	return &leaderboardRanker{hub: h, rank: rank, boards: make(map[SortField]*sharedBoard)}
+}
+
+// boardQuery - ListQuery of the shared board of field
+func boardQuery(field SortField) ListQuery {
+	return ListQuery{OrderBy: field, Descending: true, Limit: maxLeaderboardSize}
+}
+
+// topEntries - First limit entries of board, their ranks don't depend on the entries after them
+func topEntries(board []*upvoteSystem.LeaderboardEntry, limit int) []*upvoteSystem.LeaderboardEntry {
+	if len(board) > limit {
+		return board[:limit]
+	}
+	return board
+}
+
+// watch - Registers a watcher of the board of field and returns its current entries, ranking it
+// first when nobody watches it yet. Ranking runs outside the lock, when two first watchers race
+// the board stored first wins. Callers must unwatch the watcher when done
+func (r *leaderboardRanker) watch(ctx context.Context, field SortField) (*boardWatcher, []*upvoteSystem.LeaderboardEntry, error) {
+	r.mu.Lock()
+	board, found := r.boards[field]
+	if found {
+		defer r.mu.Unlock()
+		return r.addWatcher(board), board.current, nil
+	}
+	r.mu.Unlock()
+
+	// Subscribe before ranking so no vote is missed in between
+	sub, _ := r.hub.subscribe()
+	current, err := r.rank(ctx, boardQuery(field))
+	if err != nil {
+		r.hub.unsubscribe(sub)
+		return nil, nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if board, found := r.boards[field]; found {
+		r.hub.unsubscribe(sub)
+		return r.addWatcher(board), board.current, nil
+	}
+
+	runCtx, stop := context.WithCancel(context.Background())
+	board = &sharedBoard{field: field, current: current, watchers: make(map[*boardWatcher]struct{}), stop: stop}
+	r.boards[field] = board
+	go r.run(runCtx, board, sub)
+
+	return r.addWatcher(board), current, nil
+}
+
+// addWatcher - Adds a new watcher to board, callers hold r.mu
+func (r *leaderboardRanker) addWatcher(board *sharedBoard) *boardWatcher {
+	watcher := &boardWatcher{
+		board:  board,
+		boards: make(chan []*upvoteSystem.LeaderboardEntry, 1),
+		failed: make(chan error, 1),
+	}
+	board.watchers[watcher] = struct{}{}
+	return watcher
+}
+
+// unwatch - Removes the watcher and stops ranking its board once unwatched, safe to call more than once
+func (r *leaderboardRanker) unwatch(watcher *boardWatcher) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	board := watcher.board
+	delete(board.watchers, watcher)
+	if len(board.watchers) == 0 && r.boards[board.field] == board {
+		board.stop()
+		delete(r.boards, board.field)
+	}
+}
+
+// run - Ranks the board again after votes, creations and deletions until it is stopped
+func (r *leaderboardRanker) run(ctx context.Context, board *sharedBoard, sub *subscription) {
+	defer r.hub.unsubscribe(sub)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sub.dropped:
+			r.fail(board, status.Errorf(codes.ResourceExhausted, "Subscriber too slow"))
+			return
+		case e := <-sub.updates:
+			// Renames keep the order, the next ranking picks them up
+			if e.kind == eventUpdated {
+				continue
+			}
+			drainUpdates(sub)
+
+			next, err := r.rank(ctx, boardQuery(board.field))
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				r.fail(board, err)
+				return
+			}
+			r.publish(board, next)
+		}
+	}
+}
+
+// publish - Makes next the current board and offers it to every watcher, replacing the board
+// a watcher didn't take yet
+func (r *leaderboardRanker) publish(board *sharedBoard, next []*upvoteSystem.LeaderboardEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	board.current = next
+	for watcher := range board.watchers {
+		select {
+		case <-watcher.boards:
+		default:
+		}
+		// The ranker is the only sender, the buffer has room now
+		watcher.boards <- next
+	}
+}
+
+// fail - Ends the watches of board with err, the next watcher ranks the board again
+func (r *leaderboardRanker) fail(board *sharedBoard, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for watcher := range board.watchers {
+		watcher.failed <- err
+	}
+	if r.boards[board.field] == board {
+		delete(r.boards, board.field)
+	}
+	board.stop()
+}
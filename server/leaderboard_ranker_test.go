@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	upvoteSystem "github.com/RomuloSiebra/CryptoUpvoteSystem/proto/UpvoteSystem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestLeaderboardRankerSharesBoards(t *testing.T) {
+	h := newHub(4, coalesceLatest)
+	var rankings int32
+	ranker := newLeaderboardRanker(h, func(ctx context.Context, query ListQuery) ([]*upvoteSystem.LeaderboardEntry, error) {
+		n := atomic.AddInt32(&rankings, 1)
+		assert.Equal(t, maxLeaderboardSize, query.Limit)
+		return []*upvoteSystem.LeaderboardEntry{{Rank: 1, Score: float64(n)}, {Rank: 2}}, nil
+	})
+
+	first, board, err := ranker.watch(context.Background(), SortByNetVotes)
+	require.Nil(t, err)
+	assert.Len(t, board, 2)
+
+	second, _, err := ranker.watch(context.Background(), SortByNetVotes)
+	require.Nil(t, err)
+
+	// Test one ranking per event serves every watcher of the metric
+	h.publish(eventVoted, model.Crypto{ID: primitive.NewObjectID()})
+
+	for _, watcher := range []*boardWatcher{first, second} {
+		select {
+		case board := <-watcher.boards:
+			assert.Equal(t, float64(2), board[0].GetScore())
+		case <-time.After(time.Second):
+			t.Fatal("board not received")
+		}
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&rankings))
+	assert.Equal(t, []*upvoteSystem.LeaderboardEntry{{Rank: 1}}, topEntries([]*upvoteSystem.LeaderboardEntry{{Rank: 1}, {Rank: 2}}, 1))
+
+	// Test the board stops being ranked once unwatched
+	ranker.unwatch(first)
+	ranker.unwatch(second)
+	ranker.unwatch(second)
+
+	assert.Empty(t, ranker.boards)
+}
+
+func TestLeaderboardRankerFailure(t *testing.T) {
+	h := newHub(4, coalesceLatest)
+	var rankings int32
+	ranker := newLeaderboardRanker(h, func(ctx context.Context, query ListQuery) ([]*upvoteSystem.LeaderboardEntry, error) {
+		if atomic.AddInt32(&rankings, 1) > 1 {
+			return nil, errors.New("storage unavailable")
+		}
+		return nil, nil
+	})
+
+	watcher, _, err := ranker.watch(context.Background(), SortByUpvote)
+	require.Nil(t, err)
+	defer ranker.unwatch(watcher)
+
+	// Test ranking errors end the watches and the next watcher ranks again
+	h.publish(eventVoted, model.Crypto{ID: primitive.NewObjectID()})
+
+	select {
+	case err := <-watcher.failed:
+		assert.Equal(t, "storage unavailable", err.Error())
+	case <-time.After(time.Second):
+		t.Fatal("failure not received")
+	}
+
+	_, _, err = ranker.watch(context.Background(), SortByUpvote)
+	assert.Equal(t, "storage unavailable", err.Error())
+}
+
+func TestLeaderboardRankerRanksUnlocked(t *testing.T) {
+	h := newHub(4, coalesceLatest)
+	blocked := make(chan struct{})
+	release := make(chan struct{})
+	ranker := newLeaderboardRanker(h, func(ctx context.Context, query ListQuery) ([]*upvoteSystem.LeaderboardEntry, error) {
+		if query.OrderBy == SortByUpvote {
+			blocked <- struct{}{}
+			<-release
+		}
+		return nil, nil
+	})
+
+	watched := make(chan *boardWatcher, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			watcher, _, err := ranker.watch(context.Background(), SortByUpvote)
+			assert.Nil(t, err)
+			watched <- watcher
+		}()
+	}
+	<-blocked
+	<-blocked
+
+	// Test a slow ranking doesn't block the watchers of other metrics
+	watcher, _, err := ranker.watch(context.Background(), SortByNetVotes)
+	require.Nil(t, err)
+	ranker.unwatch(watcher)
+
+	// Test racing first watchers end up sharing one board
+	close(release)
+	first, second := <-watched, <-watched
+	assert.Equal(t, first.board, second.board)
+	assert.Len(t, ranker.boards, 1)
+
+	ranker.unwatch(first)
+	ranker.unwatch(second)
+	assert.Empty(t, ranker.boards)
+}
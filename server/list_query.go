@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+)
+
+// SortField - Field ordering a List result, ties are broken by ID in the same direction
+type SortField int
+
+const (
+	// SortByCreated orders by ID, ObjectIDs start with their creation time
+	SortByCreated SortField = iota
+	SortByName
+	SortByUpvote
+	SortByDownvote
+	// SortByNetVotes orders by upvotes minus downvotes
+	SortByNetVotes
+	// SortByWilson, SortByHot and SortByControversial order by the score of their Ranking
+	SortByWilson
+	SortByHot
+	SortByControversial
+)
+
+// ListQuery - Filters, order and page of CryptoRepository.List, the zero value lists everything by creation
+type ListQuery struct {
+	// NameContains and DescriptionContains keep the cryptocurrencies containing them, ignoring case
+	NameContains        string
+	DescriptionContains string
+
+	OrderBy    SortField
+	Descending bool
+
+	// After continues a previous page, only cryptocurrencies ordered after it are listed.
+	// Only its ID and the OrderBy field are used
+	After *model.Crypto
+	// Limit caps the number of cryptocurrencies, zero lists every one
+	Limit int
+}
+
+// matches - Reports whether the crypto passes the filters
+func (q ListQuery) matches(crypto model.Crypto) bool {
+	return containsFold(crypto.Name, q.NameContains) && containsFold(crypto.Description, q.DescriptionContains)
+}
+
+func containsFold(value string, substring string) bool {
+	return strings.Contains(strings.ToLower(value), strings.ToLower(substring))
+}
+
+// compare - Returns a negative number when a is listed before b, positive when after
+func (q ListQuery) compare(a model.Crypto, b model.Crypto) int {
+	result := 0
+	switch q.OrderBy {
+	case SortByName:
+		result = strings.Compare(a.Name, b.Name)
+	case SortByUpvote:
+		result = compareInt(int64(a.Upvote), int64(b.Upvote))
+	case SortByDownvote:
+		result = compareInt(int64(a.Downvote), int64(b.Downvote))
+	case SortByNetVotes:
+		result = compareInt(int64(a.Upvote)-int64(a.Downvote), int64(b.Upvote)-int64(b.Downvote))
+	default:
+		if ranking, computed := rankings[q.OrderBy]; computed {
+			result = compareFloat(ranking.Score(a), ranking.Score(b))
+		}
+	}
+	if result == 0 {
+		result = bytes.Compare(a.ID[:], b.ID[:])
+	}
+
+	if q.Descending {
+		return -result
+	}
+	return result
+}
+
+// sortValue - Value of the OrderBy field of crypto, the score of computed fields. Backends compare
+// SortByCreated on the ID themselves
+func sortValue(field SortField, crypto model.Crypto) interface{} {
+	switch field {
+	case SortByName:
+		return crypto.Name
+	case SortByUpvote:
+		return crypto.Upvote
+	case SortByDownvote:
+		return crypto.Downvote
+	case SortByNetVotes:
+		return crypto.Upvote - crypto.Downvote
+	}
+	if ranking, computed := rankings[field]; computed {
+		return ranking.Score(crypto)
+	}
+	return crypto.ID
+}
+
+func compareInt(a int64, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// apply - Filters, sorts and pages cryptos for backends without query support
+func (q ListQuery) apply(cryptos []model.Crypto) []model.Crypto {
+	var result []model.Crypto
+	for _, crypto := range cryptos {
+		if !q.matches(crypto) {
+			continue
+		}
+		if q.After != nil && q.compare(crypto, *q.After) <= 0 {
+			continue
+		}
+		result = append(result, crypto)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return q.compare(result[i], result[j]) < 0
+	})
+
+	if q.Limit > 0 && len(result) > q.Limit {
+		result = result[:q.Limit]
+	}
+	return result
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func names(cryptos []model.Crypto) []string {
+	var result []string
+	for _, crypto := range cryptos {
+		result = append(result, crypto.Name)
+	}
+	return result
+}
+
+func TestRepositoryList(t *testing.T) {
+	storage := setupDB()
+	defer clearDB()
+	ctx := context.Background()
+
+	fixtures := []model.Crypto{
+		{Name: "Bitcoin", Description: "The most valuable cryptocurrency", Upvote: 5, Downvote: 1},
+		{Name: "Ethereum", Description: "Smart contracts platform", Upvote: 3, Downvote: 0},
+		{Name: "Litecoin", Description: "The silver to Bitcoin gold", Upvote: 3, Downvote: 4},
+		{Name: "Dogecoin", Description: "Much wow", Upvote: 0, Downvote: 2},
+	}
+	for _, crypto := range fixtures {
+		crypto.ID = primitive.NewObjectID()
+		_, err := storage.Create(ctx, crypto)
+		require.Nil(t, err)
+	}
+
+	// Test the zero query lists everything by creation
+	result, err := storage.List(ctx, ListQuery{})
+
+	require.Nil(t, err)
+	assert.Equal(t, []string{"Bitcoin", "Ethereum", "Litecoin", "Dogecoin"}, names(result))
+
+	// Test every order, ties are broken by creation in the same direction
+	orders := []struct {
+		query    ListQuery
+		expected []string
+	}{
+		{ListQuery{OrderBy: SortByCreated, Descending: true}, []string{"Dogecoin", "Litecoin", "Ethereum", "Bitcoin"}},
+		{ListQuery{OrderBy: SortByName}, []string{"Bitcoin", "Dogecoin", "Ethereum", "Litecoin"}},
+		{ListQuery{OrderBy: SortByUpvote}, []string{"Dogecoin", "Ethereum", "Litecoin", "Bitcoin"}},
+		{ListQuery{OrderBy: SortByUpvote, Descending: true}, []string{"Bitcoin", "Litecoin", "Ethereum", "Dogecoin"}},
+		{ListQuery{OrderBy: SortByDownvote}, []string{"Ethereum", "Bitcoin", "Dogecoin", "Litecoin"}},
+		{ListQuery{OrderBy: SortByNetVotes, Descending: true}, []string{"Bitcoin", "Ethereum", "Litecoin", "Dogecoin"}},
+		{ListQuery{OrderBy: SortByWilson, Descending: true}, []string{"Ethereum", "Bitcoin", "Litecoin", "Dogecoin"}},
+		{ListQuery{OrderBy: SortByHot, Descending: true}, []string{"Bitcoin", "Ethereum", "Litecoin", "Dogecoin"}},
+		{ListQuery{OrderBy: SortByControversial, Descending: true}, []string{"Litecoin", "Bitcoin", "Dogecoin", "Ethereum"}},
+	}
+	for _, order := range orders {
+		result, err := storage.List(ctx, order.query)
+
+		require.Nil(t, err)
+		assert.Equal(t, order.expected, names(result))
+	}
+
+	// Test filters ignore case
+	result, err = storage.List(ctx, ListQuery{DescriptionContains: "bitcoin"})
+
+	require.Nil(t, err)
+	assert.Equal(t, []string{"Litecoin"}, names(result))
+
+	result, err = storage.List(ctx, ListQuery{NameContains: "COIN", DescriptionContains: "the"})
+
+	require.Nil(t, err)
+	assert.Equal(t, []string{"Bitcoin", "Litecoin"}, names(result))
+
+	// Test filters are not patterns
+	result, err = storage.List(ctx, ListQuery{NameContains: ".*"})
+
+	require.Nil(t, err)
+	assert.Empty(t, result)
+
+	// Test paging with a cursor on a tied sort key
+	query := ListQuery{OrderBy: SortByUpvote, Descending: true, Limit: 2}
+	var pages [][]string
+	for {
+		page, err := storage.List(ctx, query)
+		require.Nil(t, err)
+		if len(page) == 0 {
+			break
+		}
+
+		pages = append(pages, names(page))
+		last := page[len(page)-1]
+		query.After = &last
+	}
+
+	assert.Equal(t, [][]string{{"Bitcoin", "Litecoin"}, {"Ethereum", "Dogecoin"}}, pages)
+
+	// Test paging on a computed score
+	query = ListQuery{OrderBy: SortByWilson, Descending: true, NameContains: "coin", Limit: 1}
+	pages = nil
+	for {
+		page, err := storage.List(ctx, query)
+		require.Nil(t, err)
+		if len(page) == 0 {
+			break
+		}
+
+		pages = append(pages, names(page))
+		last := page[len(page)-1]
+		query.After = &last
+	}
+
+	assert.Equal(t, [][]string{{"Bitcoin"}, {"Litecoin"}, {"Dogecoin"}}, pages)
+}
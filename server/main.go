@@ -6,154 +6,204 @@ import (
 	"log"
 	"net"
 	"os"
-	"sync"
+	"strconv"
+	"strings"
 	"time"
 
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
-	"go.mongodb.org/mongo-driver/mongo/options"
-
 	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
 	upvoteSystem "github.com/RomuloSiebra/CryptoUpvoteSystem/proto/UpvoteSystem"
+	"github.com/RomuloSiebra/CryptoUpvoteSystem/tlsreload"
 	"github.com/joho/godotenv"
-	"go.mongodb.org/mongo-driver/mongo"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-var dbClient *mongo.Client
-var mongoCtx context.Context
-var db *mongo.Collection
-
-var allRegisteredClients []chan model.Crypto
-var removeClientMutex sync.Mutex
+// minHeartbeatInterval - Shortest GetVoteSumStream heartbeat interval, shorter requests are raised to it
+const minHeartbeatInterval = time.Second
 
 type server struct {
 	upvoteSystem.UnimplementedUpvoteSystemServer
-}
 
-func (*server) CreateCrypto(ctx context.Context, request *upvoteSystem.CreateCryptoRequest) (*upvoteSystem.CreateCryptoResponse, error) {
-	crypto := request.GetCrypto()
+	repository CryptoRepository
+	snapshots  SnapshotRepository
+	search     SearchRepository
+	nonces     NonceRepository
 
-	name := crypto.GetName()
-	description := crypto.GetDescription()
+	hub          *hub
+	events       EventSource
+	leaderboards *leaderboardRanker
 
-	if name == "" || description == "" {
-		return nil, status.Errorf(codes.InvalidArgument, "Empty fields")
-	}
-	data := model.Crypto{
-		ID:          primitive.NewObjectID(),
-		Name:        name,
-		Description: description,
-		Upvote:      0,
-		Downvote:    0,
-	}
+	// requireSignedVotes - Rejects votes without a wallet signature
+	requireSignedVotes bool
+	now                func() time.Time
+}
 
-	findResult := db.FindOne(mongoCtx, bson.M{"name": name})
+func newServer(storage Storage) *server {
+	h := newHub(defaultStreamBufferSize, coalesceLatest)
+	s := &server{
+		repository: storage,
+		snapshots:  storage,
+		nonces:     storage,
+		hub:        h,
+		events:     localEventSource{hub: h},
+		now:        time.Now,
+	}
+
+	// Backends without full-text search get an index of the writes going through the server
+	if search, native := storage.(SearchRepository); native {
+		s.search = search
+	} else {
+		index := newSearchIndex(storage)
+		s.repository, s.search = index, index
+	}
+	s.leaderboards = newLeaderboardRanker(h, s.leaderboard)
+	return s
+}
 
-	cryptoDup := model.Crypto{}
+func toCryptocurrency(data model.Crypto) *upvoteSystem.Cryptocurrency {
+	crypto := &upvoteSystem.Cryptocurrency{
+		Id:          data.ID.Hex(),
+		Name:        data.Name,
+		Description: data.Description,
+		Downvote:    data.Downvote,
+		Upvote:      data.Upvote,
+		Symbol:      data.Symbol,
+		Website:     data.Website,
+		LogoUrl:     data.LogoURL,
+		Tags:        data.Tags,
+	}
+	if !data.LaunchDate.IsZero() {
+		crypto.LaunchDate = timestamppb.New(data.LaunchDate)
+	}
+	return crypto
+}
 
-	if err := findResult.Decode(&cryptoDup); err == nil {
-		return nil, status.Errorf(codes.AlreadyExists, "Cryptocurrency already exists")
-	}
+func repositoryError(err error) error {
+	switch err {
+	case ErrNotFound:
+		return status.Errorf(codes.NotFound, "Couldn`t find Cryptocurrency with Object Id")
+	case ErrAlreadyExists:
+		return status.Errorf(codes.AlreadyExists, "Cryptocurrency already exists")
+	case errDuplicateVote:
+		return status.Errorf(codes.AlreadyExists, "Voter already voted on this Cryptocurrency")
+	case errVoteNotFound:
+		return status.Errorf(codes.NotFound, "Couldn`t find vote of this voter on the Cryptocurrency")
+	case ErrNonceUsed:
+		return status.Errorf(codes.AlreadyExists, "Vote nonce already used")
+	}
+	return status.Errorf(codes.Internal, fmt.Sprintf("Error: %v", err))
+}
 
-	insertResult, err := db.InsertOne(mongoCtx, data)
+func (s *server) CreateCrypto(ctx context.Context, request *upvoteSystem.CreateCryptoRequest) (*upvoteSystem.CreateCryptoResponse, error) {
+	data, err := cryptoDetails(request.GetCrypto())
 	if err != nil {
-		log.Fatal(err)
 		return nil, err
 	}
+	data.ID = primitive.NewObjectID()
+
+	data, err = s.repository.Create(ctx, data)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
 
-	crypto.Id = insertResult.InsertedID.(primitive.ObjectID).Hex()
-	crypto.Upvote = 0
-	crypto.Downvote = 0
+	s.events.Publish(eventCreated, data)
 
-	response := &upvoteSystem.CreateCryptoResponse{Crypto: crypto}
+	response := &upvoteSystem.CreateCryptoResponse{Crypto: toCryptocurrency(data)}
 
 	return response, nil
 }
 
-func (*server) ReadCryptoByID(ctx context.Context, request *upvoteSystem.ReadCryptoByIDRequest) (*upvoteSystem.ReadCryptoByIDResponse, error) {
+func (s *server) ReadCryptoByID(ctx context.Context, request *upvoteSystem.ReadCryptoByIDRequest) (*upvoteSystem.ReadCryptoByIDResponse, error) {
 	cryptoID, err := primitive.ObjectIDFromHex(request.GetId())
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, fmt.Sprintf("%v", err))
 	}
 
-	result := db.FindOne(mongoCtx, bson.M{"_id": cryptoID})
+	data, err := s.repository.GetByID(ctx, cryptoID)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+
+	response := &upvoteSystem.ReadCryptoByIDResponse{
+		Crypto: toCryptocurrency(data),
+	}
+	return response, nil
+}
 
-	data := model.Crypto{}
+func (s *server) ReadCryptoBySymbol(ctx context.Context, request *upvoteSystem.ReadCryptoBySymbolRequest) (*upvoteSystem.ReadCryptoBySymbolResponse, error) {
+	symbol := strings.TrimSpace(request.GetSymbol())
+	if symbol == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Empty symbol")
+	}
 
-	if err := result.Decode(&data); err != nil {
-		return nil, status.Errorf(codes.NotFound, "Couldn`t find Cryptocurrency with Object Id")
+	data, err := s.repository.GetBySymbol(ctx, symbol)
+	if err != nil {
+		return nil, repositoryError(err)
 	}
 
-	response := &upvoteSystem.ReadCryptoByIDResponse{
-		Crypto: &upvoteSystem.Cryptocurrency{
-			Id:          data.ID.Hex(),
-			Name:        data.Name,
-			Description: data.Description,
-			Downvote:    data.Downvote,
-			Upvote:      data.Upvote,
-		},
+	response := &upvoteSystem.ReadCryptoBySymbolResponse{
+		Crypto: toCryptocurrency(data),
 	}
 	return response, nil
 }
 
-func (*server) ReadAllCrypto(request *upvoteSystem.ReadAllCryptoRequest, stream upvoteSystem.UpvoteSystem_ReadAllCryptoServer) error {
-	data := &model.Crypto{}
+func (s *server) ReadAllCrypto(request *upvoteSystem.ReadAllCryptoRequest, stream upvoteSystem.UpvoteSystem_ReadAllCryptoServer) error {
+	query, err := listQuery(request)
+	if err != nil {
+		return err
+	}
 
-	pointer, err := db.Find(mongoCtx, bson.M{})
+	allCrypto, err := s.repository.List(stream.Context(), query)
 	if err != nil {
 		return status.Errorf(codes.Internal, fmt.Sprintf("Error: %v", err))
 	}
 
-	defer pointer.Close(mongoCtx)
+	nextPageToken := ""
+	if pageSize := pageSize(request); pageSize > 0 && len(allCrypto) > pageSize {
+		allCrypto = allCrypto[:pageSize]
+		nextPageToken = encodePageToken(query, allCrypto[pageSize-1])
+	}
 
-	for pointer.Next(mongoCtx) {
-		err := pointer.Decode(data)
-		if err != nil {
-			return status.Errorf(codes.Unavailable, fmt.Sprintf("Couldn`t decode data: %v", err))
+	for i, data := range allCrypto {
+		response := &upvoteSystem.ReadAllCryptoResponse{
+			Crypto: toCryptocurrency(data),
+		}
+		if i == len(allCrypto)-1 {
+			response.NextPageToken = nextPageToken
 		}
 
-		stream.Send(&upvoteSystem.ReadAllCryptoResponse{
-			Crypto: &upvoteSystem.Cryptocurrency{
-				Id:          data.ID.Hex(),
-				Name:        data.Name,
-				Description: data.Description,
-				Downvote:    data.Downvote,
-				Upvote:      data.Upvote,
-			},
-		})
-	}
-	if err := pointer.Err(); err != nil {
-		return status.Errorf(codes.Internal, fmt.Sprintf("Unkown mongoDB pointer error: %v", err))
+		if err := stream.Send(response); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-func (*server) DeleteCrypto(ctx context.Context, request *upvoteSystem.DeleteCryptoRequest) (*upvoteSystem.DeleteCryptoResponse, error) {
+func (s *server) DeleteCrypto(ctx context.Context, request *upvoteSystem.DeleteCryptoRequest) (*upvoteSystem.DeleteCryptoResponse, error) {
 	cryptoID, err := primitive.ObjectIDFromHex(request.GetId())
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "the provided hex string is not a valid ObjectID")
 	}
 
-	result, err := db.DeleteOne(mongoCtx, bson.M{"_id": cryptoID})
-	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "Couldn`t find Cryptocurrency with Object Id")
+	if err := s.repository.Delete(ctx, cryptoID); err != nil {
+		return nil, repositoryError(err)
 	}
 
-	if result.DeletedCount == 0 {
-		return nil, status.Errorf(codes.NotFound, "Couldn`t find Cryptocurrency with Object Id")
-	}
+	s.events.Publish(eventDeleted, model.Crypto{ID: cryptoID})
+
 	response := &upvoteSystem.DeleteCryptoResponse{
 		Success: true,
 	}
 	return response, nil
 }
 
-func (*server) UpdateCrypto(ctx context.Context, request *upvoteSystem.UpdateCryptoRequest) (*upvoteSystem.UpdateCryptoResponse, error) {
+func (s *server) UpdateCrypto(ctx context.Context, request *upvoteSystem.UpdateCryptoRequest) (*upvoteSystem.UpdateCryptoResponse, error) {
 	crypto := request.GetCrypto()
 
 	cryptoID, err := primitive.ObjectIDFromHex(crypto.GetId())
@@ -161,217 +211,273 @@ func (*server) UpdateCrypto(ctx context.Context, request *upvoteSystem.UpdateCry
 		return nil, status.Errorf(codes.InvalidArgument, fmt.Sprintf("%v", err))
 	}
 
-	name := crypto.GetName()
-	description := crypto.GetDescription()
-
-	if name == "" || description == "" {
-		return nil, status.Errorf(codes.InvalidArgument, "Empty fields")
-	}
-
-	data := bson.M{
-		"name":        name,
-		"description": description,
-	}
-
-	result := db.FindOneAndUpdate(mongoCtx, bson.M{"_id": cryptoID}, bson.M{"$set": data}, options.FindOneAndUpdate().SetReturnDocument(1))
-	if result.Err() != nil {
-		if result.Err() == mongo.ErrNoDocuments {
-			return nil, status.Errorf(codes.NotFound, "Couldn`t find Cryptocurrency with Object Id")
-		}
+	details, err := cryptoDetails(crypto)
+	if err != nil {
+		return nil, err
 	}
-	newCrypto := model.Crypto{}
+	details.ID = cryptoID
 
-	err = result.Decode(&newCrypto)
+	newCrypto, err := s.repository.Update(ctx, details)
 	if err != nil {
-		status.Errorf(codes.NotFound, fmt.Sprintf("Error: %v", err))
+		return nil, repositoryError(err)
 	}
 
+	s.events.Publish(eventUpdated, newCrypto)
+
 	response := &upvoteSystem.UpdateCryptoResponse{
-		Crypto: &upvoteSystem.Cryptocurrency{
-			Id:          newCrypto.ID.Hex(),
-			Name:        newCrypto.Name,
-			Description: newCrypto.Description,
-			Downvote:    newCrypto.Downvote,
-			Upvote:      newCrypto.Upvote,
-		},
+		Crypto: toCryptocurrency(newCrypto),
 	}
 	return response, nil
 }
 
-func removeConnectedClient(channel chan model.Crypto) {
-	removeClientMutex.Lock()
-	defer removeClientMutex.Unlock()
-	found := false
-	i := 0
-
-	for ; i < len(allRegisteredClients); i++ {
-		if allRegisteredClients[i] == channel {
-			found = true
-			break
-		}
-	}
-	if found {
-		allRegisteredClients[i] = allRegisteredClients[len(allRegisteredClients)-1]
-		allRegisteredClients = allRegisteredClients[:len(allRegisteredClients)-1]
+// updateVote - Applies decide to the vote of voterID, resolved by voterIdentity, and broadcasts the new counters
+func (s *server) updateVote(ctx context.Context, cryptoID primitive.ObjectID, voterID string, decide VoteDecision) (model.Crypto, error) {
+	newCrypto, err := s.repository.UpdateVote(ctx, cryptoID, voterID, decide)
+	if err != nil {
+		return model.Crypto{}, repositoryError(err)
 	}
 
+	s.events.Publish(eventVoted, newCrypto)
+	return newCrypto, nil
 }
 
-func broadcast(msg model.Crypto) {
-	for _, channel := range allRegisteredClients {
-		select {
-		case channel <- msg:
-		default:
+// castVote - Stores the voter vote, switching a previous vote in the opposite direction
+func (s *server) castVote(ctx context.Context, cryptoID primitive.ObjectID, voterID string, direction model.VoteDirection) (model.Crypto, error) {
+	return s.updateVote(ctx, cryptoID, voterID, func(current model.VoteDirection) (model.VoteDirection, error) {
+		if current == direction {
+			return current, errDuplicateVote
 		}
-	}
+		return direction, nil
+	})
+}
 
+// retractVote - Removes the voter vote
+func (s *server) retractVote(ctx context.Context, cryptoID primitive.ObjectID, voterID string) (model.Crypto, error) {
+	return s.updateVote(ctx, cryptoID, voterID, func(current model.VoteDirection) (model.VoteDirection, error) {
+		if current == model.NoVote {
+			return current, errVoteNotFound
+		}
+		return model.NoVote, nil
+	})
 }
 
-func (*server) UpvoteCrypto(ctx context.Context, request *upvoteSystem.UpvoteCryptoRequest) (*upvoteSystem.UpvoteCryptoResponse, error) {
+func (s *server) UpvoteCrypto(ctx context.Context, request *upvoteSystem.UpvoteCryptoRequest) (*upvoteSystem.UpvoteCryptoResponse, error) {
 
 	cryptoID, err := primitive.ObjectIDFromHex(request.GetId())
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, fmt.Sprintf("%v", err))
 	}
 
-	filter := bson.M{"_id": cryptoID}
-
-	result := db.FindOneAndUpdate(mongoCtx, filter, bson.M{"$inc": bson.M{"Upvote": 1}}, options.FindOneAndUpdate().SetReturnDocument(1))
-	if result.Err() != nil {
-		if result.Err() == mongo.ErrNoDocuments {
-			return nil, status.Errorf(codes.NotFound, "Couldn`t find Cryptocurrency with Object Id")
-		}
-	}
-	newCrypto := model.Crypto{}
-	err = result.Decode(&newCrypto)
+	voterID, err := s.voterIdentity(ctx, cryptoID, model.Upvote, request.GetVoterId(), request.GetSignature())
 	if err != nil {
-		status.Errorf(codes.NotFound, fmt.Sprintf("Error: %v", err))
+		return nil, err
 	}
 
-	broadcast(newCrypto)
+	newCrypto, err := s.castVote(ctx, cryptoID, voterID, model.Upvote)
+	if err != nil {
+		return nil, err
+	}
 
 	response := &upvoteSystem.UpvoteCryptoResponse{
-		Crypto: &upvoteSystem.Cryptocurrency{
-			Id:          newCrypto.ID.Hex(),
-			Name:        newCrypto.Name,
-			Description: newCrypto.Description,
-			Downvote:    newCrypto.Downvote,
-			Upvote:      newCrypto.Upvote,
-		},
+		Crypto: toCryptocurrency(newCrypto),
 	}
 	return response, nil
 
 }
 
-func (*server) DownvoteCrypto(ctx context.Context, request *upvoteSystem.DownvoteCryptoRequest) (*upvoteSystem.DownvoteCryptoResponse, error) {
+func (s *server) DownvoteCrypto(ctx context.Context, request *upvoteSystem.DownvoteCryptoRequest) (*upvoteSystem.DownvoteCryptoResponse, error) {
 
 	cryptoID, err := primitive.ObjectIDFromHex(request.GetId())
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, fmt.Sprintf("%v", err))
 	}
 
-	filter := bson.M{"_id": cryptoID}
-
-	result := db.FindOneAndUpdate(mongoCtx, filter, bson.M{"$inc": bson.M{"Downvote": 1}}, options.FindOneAndUpdate().SetReturnDocument(1))
-	if result.Err() != nil {
-		if result.Err() == mongo.ErrNoDocuments {
-			return nil, status.Errorf(codes.NotFound, "Couldn`t find Cryptocurrency with Object Id")
-		}
-	}
-	newCrypto := model.Crypto{}
-	err = result.Decode(&newCrypto)
+	voterID, err := s.voterIdentity(ctx, cryptoID, model.Downvote, request.GetVoterId(), request.GetSignature())
 	if err != nil {
-		status.Errorf(codes.NotFound, fmt.Sprintf("Error: %v", err))
+		return nil, err
 	}
 
-	broadcast(newCrypto)
+	newCrypto, err := s.castVote(ctx, cryptoID, voterID, model.Downvote)
+	if err != nil {
+		return nil, err
+	}
 
 	response := &upvoteSystem.DownvoteCryptoResponse{
-		Crypto: &upvoteSystem.Cryptocurrency{
-			Id:          newCrypto.ID.Hex(),
-			Name:        newCrypto.Name,
-			Description: newCrypto.Description,
-			Downvote:    newCrypto.Downvote,
-			Upvote:      newCrypto.Upvote,
-		},
+		Crypto: toCryptocurrency(newCrypto),
 	}
 	return response, nil
 
 }
 
-func (*server) GetVotesSum(ctx context.Context, request *upvoteSystem.GetVotesSumRequest) (*upvoteSystem.GetVotesSumResponse, error) {
+func (s *server) RetractVote(ctx context.Context, request *upvoteSystem.RetractVoteRequest) (*upvoteSystem.RetractVoteResponse, error) {
 	cryptoID, err := primitive.ObjectIDFromHex(request.GetId())
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, fmt.Sprintf("%v", err))
 	}
-	result := db.FindOne(mongoCtx, bson.M{"_id": cryptoID})
 
-	data := model.Crypto{}
+	voterID, err := s.voterIdentity(ctx, cryptoID, model.NoVote, request.GetVoterId(), request.GetSignature())
+	if err != nil {
+		return nil, err
+	}
 
-	if err := result.Decode(&data); err != nil {
-		return nil, status.Errorf(codes.NotFound, "Couldn`t find Cryptocurrency with Object Id")
+	newCrypto, err := s.retractVote(ctx, cryptoID, voterID)
+	if err != nil {
+		return nil, err
 	}
 
-	response := &upvoteSystem.GetVotesSumResponse{
-		Votes: data.Upvote - data.Downvote,
+	response := &upvoteSystem.RetractVoteResponse{
+		Crypto: toCryptocurrency(newCrypto),
 	}
 	return response, nil
 }
 
-func (*server) GetVoteSumStream(request *upvoteSystem.GetVoteSumStreamRequest, stream upvoteSystem.UpvoteSystem_GetVoteSumStreamServer) error {
+func (s *server) ChangeVote(ctx context.Context, request *upvoteSystem.ChangeVoteRequest) (*upvoteSystem.ChangeVoteResponse, error) {
 	cryptoID, err := primitive.ObjectIDFromHex(request.GetId())
 	if err != nil {
-		return status.Errorf(codes.InvalidArgument, fmt.Sprintf("%v", err))
+		return nil, status.Errorf(codes.InvalidArgument, fmt.Sprintf("%v", err))
 	}
 
-	result := db.FindOne(mongoCtx, bson.M{"_id": cryptoID})
+	direction := directionFromProto(request.GetDirection())
+	if direction == model.NoVote {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid vote direction")
+	}
 
-	data := model.Crypto{}
+	voterID, err := s.voterIdentity(ctx, cryptoID, direction, request.GetVoterId(), request.GetSignature())
+	if err != nil {
+		return nil, err
+	}
 
-	if err := result.Decode(&data); err != nil {
-		return status.Errorf(codes.NotFound, "Couldn`t find Cryptocurrency with Object Id")
+	newCrypto, err := s.updateVote(ctx, cryptoID, voterID, func(current model.VoteDirection) (model.VoteDirection, error) {
+		switch current {
+		case model.NoVote:
+			return current, errVoteNotFound
+		case direction:
+			return current, errDuplicateVote
+		}
+		return direction, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	ch := make(chan model.Crypto)
+	response := &upvoteSystem.ChangeVoteResponse{
+		Crypto: toCryptocurrency(newCrypto),
+	}
+	return response, nil
+}
 
-	allRegisteredClients = append(allRegisteredClients, ch)
+func (s *server) GetVotesSum(ctx context.Context, request *upvoteSystem.GetVotesSumRequest) (*upvoteSystem.GetVotesSumResponse, error) {
+	cryptoID, err := primitive.ObjectIDFromHex(request.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, fmt.Sprintf("%v", err))
+	}
 
-	streamCtx := stream.Context()
-	go func() {
-		for {
-			if streamCtx.Err() == context.Canceled || streamCtx.Err() == context.DeadlineExceeded {
+	data, err := s.repository.GetByID(ctx, cryptoID)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
 
-				removeConnectedClient(ch)
+	response := &upvoteSystem.GetVotesSumResponse{
+		Votes: data.Upvote - data.Downvote,
+	}
+	return response, nil
+}
+
+func sumStreamResponse(epoch uint64, e event) *upvoteSystem.GetVoteSumStreamResponse {
+	return &upvoteSystem.GetVoteSumStreamResponse{
+		Votes:     e.crypto.Upvote - e.crypto.Downvote,
+		Sequence:  e.sequence,
+		Timestamp: timestamppb.New(e.timestamp),
+		Epoch:     epoch,
+	}
+}
+
+func (s *server) GetVoteSumStream(request *upvoteSystem.GetVoteSumStreamRequest, stream upvoteSystem.UpvoteSystem_GetVoteSumStreamServer) error {
+	cryptoID, err := primitive.ObjectIDFromHex(request.GetId())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, fmt.Sprintf("%v", err))
+	}
 
-				close(ch)
-				fmt.Println("End stream")
-				return
+	var heartbeat <-chan time.Time
+	if request.GetHeartbeatInterval() != nil {
+		if err := request.GetHeartbeatInterval().CheckValid(); err != nil {
+			return status.Errorf(codes.InvalidArgument, "Invalid heartbeat interval")
+		}
+		if interval := request.GetHeartbeatInterval().AsDuration(); interval > 0 {
+			if interval < minHeartbeatInterval {
+				interval = minHeartbeatInterval
 			}
-			time.Sleep(time.Second)
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			heartbeat = ticker.C
 		}
+	}
 
-	}()
+	// Sequences of another epoch number the events of a previous or another server, the stream
+	// starts over from a snapshot as if it wasn't resumed
+	resumeFrom := request.GetResumeFromSequence()
+	if request.GetResumeEpoch() != s.hub.epoch {
+		resumeFrom = 0
+	}
+
+	// Subscribe before reading the counters so no update is missed in between
+	sub, missed, sequence, resumed := s.hub.resume(cryptoID, resumeFrom)
+	defer s.hub.unsubscribe(sub)
 
-	for crypto := range ch {
-		if cryptoID == crypto.ID {
-			sum := crypto.Upvote - crypto.Downvote
+	data, err := s.repository.GetByID(stream.Context(), cryptoID)
+	if err != nil {
+		return repositoryError(err)
+	}
+
+	current := &upvoteSystem.GetVoteSumStreamResponse{
+		Votes:     data.Upvote - data.Downvote,
+		Sequence:  sequence,
+		Timestamp: timestamppb.Now(),
+		Snapshot:  true,
+		Epoch:     s.hub.epoch,
+	}
+	if !resumed {
+		if err := stream.Send(current); err != nil {
+			return nil
+		}
+	}
+	for _, e := range missed {
+		current = sumStreamResponse(s.hub.epoch, e)
+		if err := stream.Send(current); err != nil {
+			return nil
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-sub.dropped:
+			return status.Errorf(codes.ResourceExhausted, "Subscriber too slow")
+		case now := <-heartbeat:
 			response := &upvoteSystem.GetVoteSumStreamResponse{
-				Votes: sum,
+				Votes:     current.GetVotes(),
+				Sequence:  current.GetSequence(),
+				Timestamp: timestamppb.New(now),
+				Heartbeat: true,
+				Epoch:     s.hub.epoch,
+			}
+			if err := stream.Send(response); err != nil {
+				return nil
+			}
+		case e := <-sub.updates:
+			if e.kind == eventDeleted {
+				return repositoryError(ErrNotFound)
 			}
-			err := stream.Send(response)
-			if err != nil {
-
-				removeConnectedClient(ch)
-				close(ch)
-				fmt.Println("End stream")
 
+			current = sumStreamResponse(s.hub.epoch, e)
+			if err := stream.Send(current); err != nil {
 				return nil
 			}
 		}
 	}
-	return nil
-
 }
+
 func main() {
 
 	err := godotenv.Load(".env")
@@ -379,21 +485,67 @@ func main() {
 		log.Fatalf("Error loading .env file")
 	}
 
-	dbClient, err := mongo.NewClient(options.Client().ApplyURI("mongodb://localhost:27017"))
+	storageBackend := os.Getenv("STORAGE_BACKEND")
+	if storageBackend == "" {
+		storageBackend = mongoBackend
+	}
 
+	repository, err := openRepository(context.Background(), storageBackend, "UpvoteSystem")
 	if err != nil {
 		log.Fatal(err)
 	}
+	fmt.Printf("Using %s storage backend\n", storageBackend)
+
+	snapshotInterval := defaultSnapshotInterval
+	if value := os.Getenv("SNAPSHOT_INTERVAL"); value != "" {
+		snapshotInterval, err = time.ParseDuration(value)
+		if err != nil || snapshotInterval < 0 {
+			log.Fatal("Error: Invalid SNAPSHOT_INTERVAL environment variable")
+		}
+	}
+	if snapshotInterval > 0 {
+		go newSnapshotScheduler(repository, repository, snapshotInterval).Run(context.Background())
+		fmt.Printf("Taking vote snapshots every %v\n", snapshotInterval)
+	}
 
-	mongoCtx = context.Background()
-	err = dbClient.Connect(mongoCtx)
+	grpcServer := newServer(repository)
 
+	if value := os.Getenv("STREAM_BUFFER_SIZE"); value != "" {
+		bufferSize, err := strconv.Atoi(value)
+		if err != nil || bufferSize < 1 {
+			log.Fatal("Error: Invalid STREAM_BUFFER_SIZE environment variable")
+		}
+		grpcServer.hub.bufferSize = bufferSize
+	}
+	if value := os.Getenv("REPLAY_BUFFER_SIZE"); value != "" {
+		replaySize, err := strconv.Atoi(value)
+		if err != nil || replaySize < 0 {
+			log.Fatal("Error: Invalid REPLAY_BUFFER_SIZE environment variable")
+		}
+		grpcServer.hub.replaySize = replaySize
+	}
+	if value := os.Getenv("SLOW_CONSUMER_POLICY"); value != "" {
+		grpcServer.hub.policy, err = parseSlowConsumerPolicy(value)
+		if err != nil {
+			log.Fatal("Error: Invalid SLOW_CONSUMER_POLICY environment variable")
+		}
+	}
+	grpcServer.requireSignedVotes = os.Getenv("REQUIRE_SIGNED_VOTES") == "true"
+
+	eventSourceName := os.Getenv("EVENT_SOURCE")
+	if eventSourceName == "" {
+		eventSourceName = localEventSourceName
+	}
+	replicaID := os.Getenv("REPLICA_ID")
+	if replicaID == "" {
+		replicaID, _ = os.Hostname()
+	}
+	grpcServer.events, err = openEventSource(eventSourceName, repository, grpcServer.hub, replicaID)
 	if err != nil {
 		log.Fatal(err)
 	}
-
-	db = dbClient.Database("UpvoteSystem").Collection("Cryptocurrency")
-	fmt.Println("Connected to MongoDB")
+	go grpcServer.events.Run(context.Background())
+	fmt.Printf("Using %s event source\n", eventSourceName)
 
 	serverPort := os.Getenv("SERVER_PORT")
 	if serverPort == "" {
@@ -405,11 +557,76 @@ func main() {
 		log.Fatalf("Error: %v", err)
 	}
 
+	var options []grpc.ServerOption
+	if certFile := os.Getenv("TLS_CERT_FILE"); certFile != "" {
+		certificates, err := tlsreload.New(certFile, os.Getenv("TLS_KEY_FILE"), os.Getenv("TLS_CLIENT_CA_FILE"))
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		reloadInterval := tlsreload.DefaultInterval
+		if value := os.Getenv("TLS_RELOAD_INTERVAL"); value != "" {
+			reloadInterval, err = time.ParseDuration(value)
+			if err != nil || reloadInterval <= 0 {
+				log.Fatal("Error: Invalid TLS_RELOAD_INTERVAL environment variable")
+			}
+		}
+		go certificates.Run(context.Background(), reloadInterval)
+
+		options = append(options, grpc.Creds(credentials.NewTLS(certificates.ServerConfig("h2"))))
+		if certificates.Pool() != nil {
+			fmt.Println("Serving TLS, client certificates required")
+		} else {
+			fmt.Println("Serving TLS")
+		}
+	}
+
+	var unaryInterceptors []grpc.UnaryServerInterceptor
+	var streamInterceptors []grpc.StreamServerInterceptor
+	if os.Getenv("AUTH_DISABLED") == "true" {
+		fmt.Println("Warning: authentication disabled, every caller is trusted")
+	} else {
+		auth, err := loadAuthenticator()
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		unaryInterceptors = append(unaryInterceptors, auth.unaryInterceptor, authorizationUnaryInterceptor)
+		streamInterceptors = append(streamInterceptors, auth.streamInterceptor, authorizationStreamInterceptor)
+	}
+
+	// Rate limits run after authentication so they can key buckets by principal
+	rateLimitStoreName := os.Getenv("RATE_LIMIT_STORE")
+	if rateLimitStoreName == "" {
+		rateLimitStoreName = memoryRateLimitStoreName
+	}
+	rateLimitStore, err := openRateLimitStore(context.Background(), rateLimitStoreName, repository)
+	if err != nil {
+		log.Fatal(err)
+	}
+	rateLimits, err := parseRateLimits(os.Getenv("RATE_LIMITS"), defaultRateLimits)
+	if err != nil {
+		log.Fatalf("Error: Invalid RATE_LIMITS environment variable: %v", err)
+	}
+	trustedProxies := os.Getenv("RATE_LIMIT_TRUSTED_PROXIES")
+	if trustedProxies == "" {
+		trustedProxies = defaultTrustedProxies
+	}
+	proxies, err := parseTrustedProxies(trustedProxies)
+	if err != nil {
+		log.Fatalf("Error: Invalid RATE_LIMIT_TRUSTED_PROXIES environment variable: %v", err)
+	}
+	limiter := newRateLimiter(rateLimitStore, rateLimits, proxies)
+	unaryInterceptors = append(unaryInterceptors, limiter.unaryInterceptor)
+	streamInterceptors = append(streamInterceptors, limiter.streamInterceptor)
+	fmt.Printf("Using %s rate limit store\n", rateLimitStoreName)
+
+	options = append(options, grpc.ChainUnaryInterceptor(unaryInterceptors...), grpc.ChainStreamInterceptor(streamInterceptors...))
+
 	fmt.Printf("Server listening at port: %s \n", serverPort)
-	s := grpc.NewServer()
+	s := grpc.NewServer(options...)
 
 	reflection.Register(s)
-	upvoteSystem.RegisterUpvoteSystemServer(s, &server{})
+	upvoteSystem.RegisterUpvoteSystemServer(s, grpcServer)
 
 	s.Serve(lis)
 
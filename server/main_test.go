@@ -6,26 +6,31 @@ import (
 	"io"
 	"log"
 	"net"
+	"os"
 	"testing"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
 	upvoteSystem "github.com/RomuloSiebra/CryptoUpvoteSystem/proto/UpvoteSystem"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func dialer() func(context.Context, string) (net.Conn, error) {
+func dialer(grpcServer *server) func(context.Context, string) (net.Conn, error) {
 	listener := bufconn.Listen(1024 * 1024)
 
 	s := grpc.NewServer()
 
-	upvoteSystem.RegisterUpvoteSystemServer(s, &server{})
+	upvoteSystem.RegisterUpvoteSystemServer(s, grpcServer)
 
 	go func() {
 		if err := s.Serve(listener); err != nil {
@@ -38,32 +43,41 @@ func dialer() func(context.Context, string) (net.Conn, error) {
 	}
 }
 
-func setupDB() {
-	dbClient, err := mongo.NewClient(options.Client().ApplyURI("mongodb://localhost:27017"))
+var testRepository Storage
 
-	if err != nil {
-		log.Fatal(err)
+// setupDB - Opens the backend chosen by STORAGE_BACKEND, defaulting to the in-memory one
+func setupDB() Storage {
+	backend := os.Getenv("STORAGE_BACKEND")
+	if backend == "" {
+		backend = memoryBackend
 	}
 
-	mongoCtx = context.Background()
-	err = dbClient.Connect(mongoCtx)
-
+	repository, err := openRepository(context.Background(), backend, "UpvoteSystemTest")
 	if err != nil {
 		log.Fatal(err)
 	}
+	fmt.Printf("Using %s storage backend\n", backend)
 
-	db = dbClient.Database("UpvoteSystemTest").Collection("Cryptocurrency")
-	fmt.Println("Connected to MongoDB")
+	testRepository = repository
+	return repository
 }
 
 func clearDB() {
-	db.Drop(mongoCtx)
+	switch repository := testRepository.(type) {
+	case *mongoRepository:
+		repository.collection.Database().Drop(context.Background())
+	case *boltRepository:
+		repository.Close()
+		os.Remove(repository.path)
+	case *postgresRepository:
+		repository.db.Exec("DROP SCHEMA " + pq.QuoteIdentifier(repository.schema) + " CASCADE")
+		repository.Close()
+	}
 }
 
 func TestCreateCrypto(t *testing.T) {
-	setupDB()
+	grpcServer := newServer(setupDB())
 	defer clearDB()
-	grpcServer := server{}
 
 	// Test request with empty fields
 	emptyRequest := &upvoteSystem.CreateCryptoRequest{
@@ -102,12 +116,96 @@ func TestCreateCrypto(t *testing.T) {
 	require.NotNil(t, err)
 	assert.Equal(t, "rpc error: code = AlreadyExists desc = Cryptocurrency already exists", err.Error())
 
+	// Test names are unique ignoring case
+	_, err = grpcServer.CreateCrypto(context.Background(), &upvoteSystem.CreateCryptoRequest{
+		Crypto: &upvoteSystem.Cryptocurrency{Name: "bitcoin", Description: "The most valuable cryptocurrency"},
+	})
+
+	require.NotNil(t, err)
+	assert.Equal(t, "rpc error: code = AlreadyExists desc = Cryptocurrency already exists", err.Error())
+
+	// Test invalid metadata
+	invalidRequests := map[string]*upvoteSystem.Cryptocurrency{
+		"Invalid symbol":      {Symbol: "BT C"},
+		"Invalid website":     {Website: "bitcoin.org"},
+		"Invalid logo URL":    {LogoUrl: "ftp://bitcoin.org/logo.png"},
+		"Invalid launch date": {LaunchDate: &timestamppb.Timestamp{Nanos: -1}},
+		"Invalid tag":         {Tags: []string{"pow", " "}},
+	}
+	for message, crypto := range invalidRequests {
+		crypto.Name = "Litecoin"
+		crypto.Description = "The silver to Bitcoin gold"
+
+		_, err = grpcServer.CreateCrypto(context.Background(), &upvoteSystem.CreateCryptoRequest{Crypto: crypto})
+
+		require.NotNil(t, err)
+		assert.Equal(t, "rpc error: code = InvalidArgument desc = "+message, err.Error())
+	}
+
+	// Test metadata is normalised
+	launchDate := timestamppb.New(time.Date(2011, time.October, 7, 0, 0, 0, 0, time.UTC))
+	response, err = grpcServer.CreateCrypto(context.Background(), &upvoteSystem.CreateCryptoRequest{
+		Crypto: &upvoteSystem.Cryptocurrency{
+			Name:        "Litecoin",
+			Description: "The silver to Bitcoin gold",
+			Symbol:      " ltc ",
+			Website:     "https://litecoin.org",
+			LogoUrl:     "https://litecoin.org/logo.png",
+			LaunchDate:  launchDate,
+			Tags:        []string{"PoW", "payments", "pow"},
+		},
+	})
+
+	require.Nil(t, err)
+
+	assert.Equal(t, "LTC", response.GetCrypto().GetSymbol())
+	assert.Equal(t, "https://litecoin.org", response.GetCrypto().GetWebsite())
+	assert.Equal(t, "https://litecoin.org/logo.png", response.GetCrypto().GetLogoUrl())
+	assert.True(t, launchDate.AsTime().Equal(response.GetCrypto().GetLaunchDate().AsTime()))
+	assert.Equal(t, []string{"pow", "payments"}, response.GetCrypto().GetTags())
+
+	// Test symbols are unique ignoring case
+	_, err = grpcServer.CreateCrypto(context.Background(), &upvoteSystem.CreateCryptoRequest{
+		Crypto: &upvoteSystem.Cryptocurrency{Name: "Litecoin Cash", Description: "Litecoin fork", Symbol: "Ltc"},
+	})
+
+	require.NotNil(t, err)
+	assert.Equal(t, "rpc error: code = AlreadyExists desc = Cryptocurrency already exists", err.Error())
+}
+
+func TestReadCryptoBySymbol(t *testing.T) {
+	grpcServer := newServer(setupDB())
+	defer clearDB()
+
+	// Test request with empty symbol
+	_, err := grpcServer.ReadCryptoBySymbol(context.Background(), &upvoteSystem.ReadCryptoBySymbolRequest{Symbol: " "})
+
+	require.NotNil(t, err)
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = Empty symbol", err.Error())
+
+	// Test request with unknown symbol
+	_, err = grpcServer.ReadCryptoBySymbol(context.Background(), &upvoteSystem.ReadCryptoBySymbolRequest{Symbol: "BTC"})
+
+	require.NotNil(t, err)
+	assert.Equal(t, "rpc error: code = NotFound desc = Couldn`t find Cryptocurrency with Object Id", err.Error())
+
+	// Test the lookup ignores case
+	cryptoResponse, err := grpcServer.CreateCrypto(context.Background(), &upvoteSystem.CreateCryptoRequest{
+		Crypto: &upvoteSystem.Cryptocurrency{Name: "Bitcoin", Description: "The most valuable cryptocurrency", Symbol: "BTC"},
+	})
+
+	require.Nil(t, err)
+
+	response, err := grpcServer.ReadCryptoBySymbol(context.Background(), &upvoteSystem.ReadCryptoBySymbolRequest{Symbol: "btc"})
+
+	require.Nil(t, err)
+	assert.Equal(t, cryptoResponse.GetCrypto().GetId(), response.GetCrypto().GetId())
+	assert.Equal(t, "BTC", response.GetCrypto().GetSymbol())
 }
 
 func TestReadCryptoByID(t *testing.T) {
-	setupDB()
+	grpcServer := newServer(setupDB())
 	defer clearDB()
-	grpcServer := server{}
 
 	// Test request with empty ID
 	emptyIDRequest := &upvoteSystem.ReadCryptoByIDRequest{
@@ -159,15 +257,14 @@ func TestReadCryptoByID(t *testing.T) {
 }
 
 func TestReadAllCrypto(t *testing.T) {
-	setupDB()
+	grpcServer := newServer(setupDB())
 	defer clearDB()
-	grpcServer := server{}
 
 	// Test with no crypto found on DB
 	var allCreatedCrypto []*upvoteSystem.CreateCryptoResponse
 
 	ctx := context.Background()
-	conn, err := grpc.DialContext(ctx, "", grpc.WithInsecure(), grpc.WithContextDialer(dialer()))
+	conn, err := grpc.DialContext(ctx, "", grpc.WithInsecure(), grpc.WithContextDialer(dialer(grpcServer)))
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -243,7 +340,7 @@ func TestReadAllCrypto(t *testing.T) {
 
 	require.Nil(t, err)
 	for i, crypto := range cryptoTest {
-		assert.Equal(t, crypto.GetId(), result[i].GetCrypto().GetId())
+		assert.Equal(t, allCreatedCrypto[i].GetCrypto().GetId(), result[i].GetCrypto().GetId())
 		assert.Equal(t, crypto.GetName(), result[i].GetCrypto().GetName())
 		assert.Equal(t, crypto.GetDescription(), result[i].GetCrypto().GetDescription())
 		assert.Equal(t, crypto.GetUpvote(), result[i].GetCrypto().GetUpvote())
@@ -252,10 +349,107 @@ func TestReadAllCrypto(t *testing.T) {
 
 }
 
+// readAllCrypto - Collects the names of a ReadAllCrypto page and its next page token
+func readAllCrypto(ctx context.Context, client upvoteSystem.UpvoteSystemClient, request *upvoteSystem.ReadAllCryptoRequest) ([]string, string, error) {
+	stream, err := client.ReadAllCrypto(ctx, request)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var names []string
+	var nextPageToken string
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return names, nextPageToken, nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		names = append(names, resp.GetCrypto().GetName())
+		nextPageToken = resp.GetNextPageToken()
+	}
+}
+
+func TestReadAllCryptoPages(t *testing.T) {
+	grpcServer := newServer(setupDB())
+	defer clearDB()
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithInsecure(), grpc.WithContextDialer(dialer(grpcServer)))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := upvoteSystem.NewUpvoteSystemClient(conn)
+
+	for i, name := range []string{"Bitcoin", "Ethereum", "Litecoin", "Dogecoin", "Cardano"} {
+		createRequest := &upvoteSystem.CreateCryptoRequest{
+			Crypto: &upvoteSystem.Cryptocurrency{
+				Name:        name,
+				Description: fmt.Sprintf("Cryptocurrency number %d", i),
+			},
+		}
+		_, err := grpcServer.CreateCrypto(context.Background(), createRequest)
+
+		require.Nil(t, err)
+	}
+
+	// Test invalid requests
+	_, _, err = readAllCrypto(ctx, client, &upvoteSystem.ReadAllCryptoRequest{PageSize: -1})
+
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = Invalid page size", err.Error())
+
+	_, _, err = readAllCrypto(ctx, client, &upvoteSystem.ReadAllCryptoRequest{OrderBy: upvoteSystem.CryptoOrder(9)})
+
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = Invalid order", err.Error())
+
+	_, _, err = readAllCrypto(ctx, client, &upvoteSystem.ReadAllCryptoRequest{PageToken: "invalid"})
+
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = Invalid page token", err.Error())
+
+	// Test paging by name
+	request := &upvoteSystem.ReadAllCryptoRequest{PageSize: 2, OrderBy: upvoteSystem.CryptoOrder_ORDER_NAME}
+	var pages [][]string
+	for {
+		page, nextPageToken, err := readAllCrypto(ctx, client, request)
+
+		require.Nil(t, err)
+
+		pages = append(pages, page)
+		if nextPageToken == "" {
+			break
+		}
+		request.PageToken = nextPageToken
+	}
+
+	assert.Equal(t, [][]string{{"Bitcoin", "Cardano"}, {"Dogecoin", "Ethereum"}, {"Litecoin"}}, pages)
+
+	// Test a token is only valid for its order
+	_, nextPageToken, err := readAllCrypto(ctx, client, &upvoteSystem.ReadAllCryptoRequest{PageSize: 2})
+
+	require.Nil(t, err)
+
+	_, _, err = readAllCrypto(ctx, client, &upvoteSystem.ReadAllCryptoRequest{PageSize: 2, PageToken: nextPageToken, Descending: true})
+
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = Invalid page token", err.Error())
+
+	// Test filters
+	page, nextPageToken, err := readAllCrypto(ctx, client, &upvoteSystem.ReadAllCryptoRequest{
+		NameFilter:        "COIN",
+		DescriptionFilter: "number",
+		Descending:        true,
+	})
+
+	require.Nil(t, err)
+
+	assert.Equal(t, []string{"Dogecoin", "Litecoin", "Bitcoin"}, page)
+	assert.Empty(t, nextPageToken)
+}
+
 func TestDeleteCrypto(t *testing.T) {
-	setupDB()
+	grpcServer := newServer(setupDB())
 	defer clearDB()
-	grpcServer := server{}
 
 	// Test request with empty ID
 	emptyIDRequest := &upvoteSystem.DeleteCryptoRequest{
@@ -303,9 +497,8 @@ func TestDeleteCrypto(t *testing.T) {
 }
 
 func TestUpdateCrypto(t *testing.T) {
-	setupDB()
+	grpcServer := newServer(setupDB())
 	defer clearDB()
-	grpcServer := server{}
 
 	// Test request with empty ID
 	emptyIDRequest := &upvoteSystem.UpdateCryptoRequest{
@@ -382,9 +575,8 @@ func TestUpdateCrypto(t *testing.T) {
 }
 
 func TestUpvoteCrypto(t *testing.T) {
-	setupDB()
+	grpcServer := newServer(setupDB())
 	defer clearDB()
-	grpcServer := server{}
 
 	// Test request with empty ID
 	emptyIDRequest := &upvoteSystem.UpvoteCryptoRequest{
@@ -398,7 +590,8 @@ func TestUpvoteCrypto(t *testing.T) {
 
 	// Test request with valid ID but not found on DB
 	NotFoundIDRequest := &upvoteSystem.UpvoteCryptoRequest{
-		Id: primitive.NewObjectID().Hex(),
+		Id:      primitive.NewObjectID().Hex(),
+		VoterId: "voter",
 	}
 	_, err = grpcServer.UpvoteCrypto(context.Background(), NotFoundIDRequest)
 
@@ -419,7 +612,8 @@ func TestUpvoteCrypto(t *testing.T) {
 	require.Nil(t, err)
 
 	validRequest := &upvoteSystem.UpvoteCryptoRequest{
-		Id: cryptoResponse.GetCrypto().GetId(),
+		Id:      cryptoResponse.GetCrypto().GetId(),
+		VoterId: "voter",
 	}
 	response, err := grpcServer.UpvoteCrypto(context.Background(), validRequest)
 
@@ -431,12 +625,41 @@ func TestUpvoteCrypto(t *testing.T) {
 	assert.Equal(t, cryptoResponse.GetCrypto().GetUpvote()+1, response.GetCrypto().GetUpvote())
 	assert.Equal(t, cryptoResponse.GetCrypto().GetDownvote(), response.GetCrypto().GetDownvote())
 
+	// Test request without voter identity
+	anonymousRequest := &upvoteSystem.UpvoteCryptoRequest{
+		Id: cryptoResponse.GetCrypto().GetId(),
+	}
+	_, err = grpcServer.UpvoteCrypto(context.Background(), anonymousRequest)
+
+	require.NotNil(t, err)
+
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = Missing voter identity", err.Error())
+
+	// Test same voter voting twice, identified through metadata
+	voterCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(voterIDMetadataKey, "voter"))
+	_, err = grpcServer.UpvoteCrypto(voterCtx, anonymousRequest)
+
+	require.NotNil(t, err)
+
+	assert.Equal(t, "rpc error: code = AlreadyExists desc = Voter already voted on this Cryptocurrency", err.Error())
+
+	// Test same voter switching its vote
+	switchRequest := &upvoteSystem.DownvoteCryptoRequest{
+		Id:      cryptoResponse.GetCrypto().GetId(),
+		VoterId: "voter",
+	}
+	switchResponse, err := grpcServer.DownvoteCrypto(context.Background(), switchRequest)
+
+	require.Nil(t, err)
+
+	assert.Equal(t, cryptoResponse.GetCrypto().GetUpvote(), switchResponse.GetCrypto().GetUpvote())
+	assert.Equal(t, cryptoResponse.GetCrypto().GetDownvote()+1, switchResponse.GetCrypto().GetDownvote())
+
 }
 
 func TestDownvoteCrypto(t *testing.T) {
-	setupDB()
+	grpcServer := newServer(setupDB())
 	defer clearDB()
-	grpcServer := server{}
 
 	// Test request with empty ID
 	emptyIDRequest := &upvoteSystem.DownvoteCryptoRequest{
@@ -450,7 +673,8 @@ func TestDownvoteCrypto(t *testing.T) {
 
 	// Test request with valid ID but not found on DB
 	NotFoundIDRequest := &upvoteSystem.DownvoteCryptoRequest{
-		Id: primitive.NewObjectID().Hex(),
+		Id:      primitive.NewObjectID().Hex(),
+		VoterId: "voter",
 	}
 	_, err = grpcServer.DownvoteCrypto(context.Background(), NotFoundIDRequest)
 
@@ -471,7 +695,8 @@ func TestDownvoteCrypto(t *testing.T) {
 	require.Nil(t, err)
 
 	validRequest := &upvoteSystem.DownvoteCryptoRequest{
-		Id: cryptoResponse.GetCrypto().GetId(),
+		Id:      cryptoResponse.GetCrypto().GetId(),
+		VoterId: "voter",
 	}
 	response, err := grpcServer.DownvoteCrypto(context.Background(), validRequest)
 
@@ -486,9 +711,8 @@ func TestDownvoteCrypto(t *testing.T) {
 }
 
 func TestGetVotesSum(t *testing.T) {
-	setupDB()
+	grpcServer := newServer(setupDB())
 	defer clearDB()
-	grpcServer := server{}
 
 	// Test request with empty ID
 	emptyIDRequest := &upvoteSystem.GetVotesSumRequest{
@@ -526,7 +750,8 @@ func TestGetVotesSum(t *testing.T) {
 
 	for i := 0; i < 5; i++ {
 		validRequest := &upvoteSystem.UpvoteCryptoRequest{
-			Id: cryptoResponse.GetCrypto().GetId(),
+			Id:      cryptoResponse.GetCrypto().GetId(),
+			VoterId: fmt.Sprintf("upvoter-%d", i),
 		}
 		_, err = grpcServer.UpvoteCrypto(context.Background(), validRequest)
 
@@ -535,7 +760,8 @@ func TestGetVotesSum(t *testing.T) {
 
 	for i := 0; i < 2; i++ {
 		validRequest := &upvoteSystem.DownvoteCryptoRequest{
-			Id: cryptoResponse.GetCrypto().GetId(),
+			Id:      cryptoResponse.GetCrypto().GetId(),
+			VoterId: fmt.Sprintf("downvoter-%d", i),
 		}
 		downvoteResponse, err = grpcServer.DownvoteCrypto(context.Background(), validRequest)
 
@@ -554,11 +780,11 @@ func TestGetVotesSum(t *testing.T) {
 }
 
 func TestGetVotesSumStream(t *testing.T) {
-	setupDB()
+	grpcServer := newServer(setupDB())
 	defer clearDB()
-	grpcServer := server{}
-	ctx := context.Background()
-	conn, err := grpc.DialContext(ctx, "", grpc.WithInsecure(), grpc.WithContextDialer(dialer()))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithInsecure(), grpc.WithContextDialer(dialer(grpcServer)))
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -572,31 +798,23 @@ func TestGetVotesSumStream(t *testing.T) {
 
 	stream, err := client.GetVoteSumStream(ctx, request)
 
-	var result []*upvoteSystem.GetVoteSumStreamResponse
-	var resp *upvoteSystem.GetVoteSumStreamResponse
+	require.Nil(t, err)
 
-	done := make(chan bool)
+	// Each goroutine owns its variables and reports through channels
+	recvErr := make(chan error, 1)
 
 	go func() {
 		for {
-			resp, err = stream.Recv()
-			if err == io.EOF {
-				done <- true
-				return
-			}
-			if err != nil {
-				done <- true
+			if _, err := stream.Recv(); err != nil {
+				recvErr <- err
 				return
 			}
-
-			result = append(result, resp)
-
 		}
 	}()
 
-	<-done
+	err = <-recvErr
 	require.NotNil(t, err)
-	require.Nil(t, result)
+	require.NotEqual(t, io.EOF, err)
 
 	// Test valid live update
 	createRequest := &upvoteSystem.CreateCryptoRequest{
@@ -616,51 +834,940 @@ func TestGetVotesSumStream(t *testing.T) {
 
 	stream, err = client.GetVoteSumStream(ctx, request)
 
+	require.Nil(t, err)
+
+	responses := make(chan *upvoteSystem.GetVoteSumStreamResponse)
+
 	go func() {
 		for {
-			resp, err = stream.Recv()
-			if err == io.EOF {
-				done <- true
+			resp, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
 				return
 			}
-			if err != nil {
-				done <- true
+			select {
+			case responses <- resp:
+			case <-ctx.Done():
 				return
 			}
-
 		}
 	}()
 
+	voteErr := make(chan error, 1)
+
 	go func() {
+		// Give the server time to register the subscriber, in-memory votes are instant
+		time.Sleep(100 * time.Millisecond)
 
 		for i := 0; i < 4; i++ {
 			upvoteRequest := &upvoteSystem.UpvoteCryptoRequest{
-				Id: cryptoResponse.GetCrypto().GetId(),
+				Id:      cryptoResponse.GetCrypto().GetId(),
+				VoterId: fmt.Sprintf("upvoter-%d", i),
+			}
+			if _, err := grpcServer.UpvoteCrypto(context.Background(), upvoteRequest); err != nil {
+				voteErr <- err
+				return
 			}
-			_, err = grpcServer.UpvoteCrypto(context.Background(), upvoteRequest)
-
-			require.Nil(t, err)
-
 		}
 
 		for i := 0; i < 2; i++ {
 			downvoteRequest := &upvoteSystem.DownvoteCryptoRequest{
-				Id: cryptoResponse.GetCrypto().GetId(),
+				Id:      cryptoResponse.GetCrypto().GetId(),
+				VoterId: fmt.Sprintf("downvoter-%d", i),
+			}
+			if _, err := grpcServer.DownvoteCrypto(context.Background(), downvoteRequest); err != nil {
+				voteErr <- err
+				return
 			}
-			_, err = grpcServer.DownvoteCrypto(context.Background(), downvoteRequest)
+		}
+		voteErr <- nil
+	}()
 
+	var votes int32
+	timeout := time.After(2 * time.Second)
+	for votesDone := false; !votesDone || votes != 2; {
+		select {
+		case resp := <-responses:
+			votes = resp.GetVotes()
+		case err := <-voteErr:
 			require.Nil(t, err)
+			votesDone = true
+		case err := <-recvErr:
+			require.Nil(t, err)
+		case <-timeout:
+			require.Equal(t, int32(2), votes)
+			return
+		}
+	}
+
+	require.Equal(t, int32(2), votes)
+}
+
+func TestGetVotesSumStreamHeartbeat(t *testing.T) {
+	grpcServer := newServer(setupDB())
+	defer clearDB()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithInsecure(), grpc.WithContextDialer(dialer(grpcServer)))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := upvoteSystem.NewUpvoteSystemClient(conn)
+
+	createRequest := &upvoteSystem.CreateCryptoRequest{
+		Crypto: &upvoteSystem.Cryptocurrency{
+			Name:        "Bitcoin",
+			Description: "The most valuable cryptocurrency",
+		},
+	}
+
+	cryptoResponse, err := grpcServer.CreateCrypto(context.Background(), createRequest)
+
+	require.Nil(t, err)
+
+	upvoteRequest := &upvoteSystem.UpvoteCryptoRequest{
+		Id:      cryptoResponse.GetCrypto().GetId(),
+		VoterId: "first-voter",
+	}
+	_, err = grpcServer.UpvoteCrypto(context.Background(), upvoteRequest)
+
+	require.Nil(t, err)
+
+	// Test invalid heartbeat interval
+	stream, err := client.GetVoteSumStream(ctx, &upvoteSystem.GetVoteSumStreamRequest{
+		Id:                cryptoResponse.GetCrypto().GetId(),
+		HeartbeatInterval: &durationpb.Duration{Seconds: 1, Nanos: -1},
+	})
+
+	require.Nil(t, err)
+
+	_, err = stream.Recv()
+
+	require.NotNil(t, err)
+
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = Invalid heartbeat interval", err.Error())
+
+	// Test the current sum is sent before any vote
+	stream, err = client.GetVoteSumStream(ctx, &upvoteSystem.GetVoteSumStreamRequest{
+		Id:                cryptoResponse.GetCrypto().GetId(),
+		HeartbeatInterval: durationpb.New(time.Second),
+	})
+
+	require.Nil(t, err)
+
+	resp, err := stream.Recv()
+
+	require.Nil(t, err)
+
+	assert.Equal(t, int32(1), resp.GetVotes())
+	assert.Equal(t, uint64(2), resp.GetSequence())
+	assert.False(t, resp.GetHeartbeat())
+	assert.True(t, resp.GetSnapshot())
+	assert.NotNil(t, resp.GetTimestamp())
+
+	// Test updates carry the next sequence
+	upvoteRequest.VoterId = "second-voter"
+	_, err = grpcServer.UpvoteCrypto(context.Background(), upvoteRequest)
+
+	require.Nil(t, err)
+
+	resp, err = stream.Recv()
+
+	require.Nil(t, err)
+
+	assert.Equal(t, int32(2), resp.GetVotes())
+	assert.Equal(t, uint64(3), resp.GetSequence())
+	assert.False(t, resp.GetHeartbeat())
+
+	// Test heartbeats repeat the latest sum and sequence
+	resp, err = stream.Recv()
+
+	require.Nil(t, err)
+
+	assert.Equal(t, int32(2), resp.GetVotes())
+	assert.Equal(t, uint64(3), resp.GetSequence())
+	assert.True(t, resp.GetHeartbeat())
+
+	// Test deleting the cryptocurrency ends the stream
+	_, err = grpcServer.DeleteCrypto(context.Background(), &upvoteSystem.DeleteCryptoRequest{Id: cryptoResponse.GetCrypto().GetId()})
 
+	require.Nil(t, err)
+
+	for err == nil {
+		_, err = stream.Recv()
+	}
+
+	assert.Equal(t, "rpc error: code = NotFound desc = Couldn`t find Cryptocurrency with Object Id", err.Error())
+}
+
+func TestVoteStream(t *testing.T) {
+	grpcServer := newServer(setupDB())
+	defer clearDB()
+	ctx := metadata.AppendToOutgoingContext(context.Background(), voterIDMetadataKey, "kiosk")
+	conn, err := grpc.DialContext(ctx, "", grpc.WithInsecure(), grpc.WithContextDialer(dialer(grpcServer)))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := upvoteSystem.NewUpvoteSystemClient(conn)
+
+	createRequest := &upvoteSystem.CreateCryptoRequest{
+		Crypto: &upvoteSystem.Cryptocurrency{
+			Name:        "Bitcoin",
+			Description: "The most valuable cryptocurrency",
+		},
+	}
+
+	cryptoResponse, err := grpcServer.CreateCrypto(context.Background(), createRequest)
+
+	require.Nil(t, err)
+
+	id := cryptoResponse.GetCrypto().GetId()
+
+	stream, err := client.VoteStream(ctx)
+
+	require.Nil(t, err)
+
+	// Commands are sent without waiting for acknowledgements, failures don't end the stream
+	commands := []*upvoteSystem.VoteCommand{
+		{RequestId: "invalid-id", Id: ""},
+		{RequestId: "retract-without-vote", Id: id, Direction: upvoteSystem.VoteDirection_VOTE_NONE},
+		{RequestId: "upvote", Id: id, Direction: upvoteSystem.VoteDirection_VOTE_UP},
+		{RequestId: "duplicate", Id: id, Direction: upvoteSystem.VoteDirection_VOTE_UP},
+		{RequestId: "other-voter", Id: id, VoterId: "bot", Direction: upvoteSystem.VoteDirection_VOTE_DOWN},
+		{RequestId: "invalid-direction", Id: id, Direction: upvoteSystem.VoteDirection(7)},
+		{RequestId: "switch", Id: id, Direction: upvoteSystem.VoteDirection_VOTE_DOWN},
+		{RequestId: "retract", Id: id, Direction: upvoteSystem.VoteDirection_VOTE_NONE},
+		{RequestId: "not-found", Id: primitive.NewObjectID().Hex(), Direction: upvoteSystem.VoteDirection_VOTE_UP},
+	}
+	for _, command := range commands {
+		require.Nil(t, stream.Send(command))
+	}
+	require.Nil(t, stream.CloseSend())
+
+	expected := []struct {
+		code     codes.Code
+		message  string
+		upvote   int32
+		downvote int32
+	}{
+		{codes.InvalidArgument, "the provided hex string is not a valid ObjectID", 0, 0},
+		{codes.NotFound, "Couldn`t find vote of this voter on the Cryptocurrency", 0, 0},
+		{codes.OK, "", 1, 0},
+		{codes.AlreadyExists, "Voter already voted on this Cryptocurrency", 0, 0},
+		{codes.OK, "", 1, 1},
+		{codes.InvalidArgument, "Invalid vote direction", 0, 0},
+		{codes.OK, "", 0, 2},
+		{codes.OK, "", 0, 1},
+		{codes.NotFound, "Couldn`t find Cryptocurrency with Object Id", 0, 0},
+	}
+
+	for i, ack := range expected {
+		resp, err := stream.Recv()
+
+		require.Nil(t, err)
+
+		assert.Equal(t, commands[i].GetRequestId(), resp.GetRequestId())
+		assert.Equal(t, uint32(ack.code), resp.GetCode())
+		assert.Equal(t, ack.message, resp.GetMessage())
+		assert.Equal(t, ack.upvote, resp.GetCrypto().GetUpvote())
+		assert.Equal(t, ack.downvote, resp.GetCrypto().GetDownvote())
+		if ack.code != codes.OK {
+			assert.Nil(t, resp.GetCrypto())
 		}
-		// Sleep to give stream time to receive before finish Test
-		time.Sleep(200 * time.Microsecond)
-		done <- true
-	}()
+	}
 
-	<-done
+	_, err = stream.Recv()
+
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestGetVotesSumStreamResume(t *testing.T) {
+	grpcServer := newServer(setupDB())
+	defer clearDB()
+	grpcServer.hub.replaySize = 2
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithInsecure(), grpc.WithContextDialer(dialer(grpcServer)))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := upvoteSystem.NewUpvoteSystemClient(conn)
+
+	createRequest := &upvoteSystem.CreateCryptoRequest{
+		Crypto: &upvoteSystem.Cryptocurrency{
+			Name:        "Bitcoin",
+			Description: "The most valuable cryptocurrency",
+		},
+	}
+
+	cryptoResponse, err := grpcServer.CreateCrypto(context.Background(), createRequest)
 
 	require.Nil(t, err)
 
-	require.Equal(t, int32(2), resp.GetVotes())
+	// Sequence 1 is the creation, votes take sequences 2 to 4
+	for i := 0; i < 3; i++ {
+		upvoteRequest := &upvoteSystem.UpvoteCryptoRequest{
+			Id:      cryptoResponse.GetCrypto().GetId(),
+			VoterId: fmt.Sprintf("upvoter-%d", i),
+		}
+		_, err = grpcServer.UpvoteCrypto(context.Background(), upvoteRequest)
+
+		require.Nil(t, err)
+	}
+
+	// Test a sequence of another epoch falls back to a snapshot followed by the live changes
+	otherEpoch, err := client.GetVoteSumStream(ctx, &upvoteSystem.GetVoteSumStreamRequest{
+		Id:                 cryptoResponse.GetCrypto().GetId(),
+		ResumeFromSequence: 2,
+		ResumeEpoch:        grpcServer.hub.epoch + 1,
+	})
+
+	require.Nil(t, err)
+
+	resp, err := otherEpoch.Recv()
+
+	require.Nil(t, err)
+
+	assert.Equal(t, uint64(4), resp.GetSequence())
+	assert.Equal(t, int32(3), resp.GetVotes())
+	assert.True(t, resp.GetSnapshot())
+	assert.Equal(t, grpcServer.hub.epoch, resp.GetEpoch())
+
+	// Test resuming within the replay buffer sends the missed changes first
+	stream, err := client.GetVoteSumStream(ctx, &upvoteSystem.GetVoteSumStreamRequest{
+		Id:                 cryptoResponse.GetCrypto().GetId(),
+		ResumeFromSequence: 2,
+		ResumeEpoch:        grpcServer.hub.epoch,
+	})
+
+	require.Nil(t, err)
+
+	for sequence := uint64(3); sequence <= 4; sequence++ {
+		resp, err := stream.Recv()
+
+		require.Nil(t, err)
+
+		assert.Equal(t, sequence, resp.GetSequence())
+		assert.Equal(t, int32(sequence-1), resp.GetVotes())
+		assert.False(t, resp.GetSnapshot())
+		assert.Equal(t, grpcServer.hub.epoch, resp.GetEpoch())
+	}
+
+	// Test live changes follow the missed ones
+	downvoteRequest := &upvoteSystem.DownvoteCryptoRequest{
+		Id:      cryptoResponse.GetCrypto().GetId(),
+		VoterId: "downvoter",
+	}
+	_, err = grpcServer.DownvoteCrypto(context.Background(), downvoteRequest)
+
+	require.Nil(t, err)
+
+	for _, live := range []upvoteSystem.UpvoteSystem_GetVoteSumStreamClient{stream, otherEpoch} {
+		resp, err = live.Recv()
+
+		require.Nil(t, err)
+
+		assert.Equal(t, uint64(5), resp.GetSequence())
+		assert.Equal(t, int32(2), resp.GetVotes())
+		assert.False(t, resp.GetSnapshot())
+	}
+
+	// Test resuming from a sequence no longer retained falls back to a snapshot
+	stream, err = client.GetVoteSumStream(ctx, &upvoteSystem.GetVoteSumStreamRequest{
+		Id:                 cryptoResponse.GetCrypto().GetId(),
+		ResumeFromSequence: 1,
+		ResumeEpoch:        grpcServer.hub.epoch,
+	})
+
+	require.Nil(t, err)
+
+	resp, err = stream.Recv()
+
+	require.Nil(t, err)
+
+	assert.Equal(t, uint64(5), resp.GetSequence())
+	assert.Equal(t, int32(2), resp.GetVotes())
+	assert.True(t, resp.GetSnapshot())
+}
+
+func TestWatchCryptos(t *testing.T) {
+	grpcServer := newServer(setupDB())
+	defer clearDB()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithInsecure(), grpc.WithContextDialer(dialer(grpcServer)))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := upvoteSystem.NewUpvoteSystemClient(conn)
+
+	// Test invalid id
+	stream, err := client.WatchCryptos(ctx, &upvoteSystem.WatchCryptosRequest{Ids: []string{""}})
+
+	require.Nil(t, err)
+
+	_, err = stream.Recv()
+
+	require.NotNil(t, err)
+
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = the provided hex string is not a valid ObjectID", err.Error())
+
+	// Test watching every cryptocurrency receives every change
+	allStream, err := client.WatchCryptos(ctx, &upvoteSystem.WatchCryptosRequest{})
+
+	require.Nil(t, err)
+
+	// Give the server time to register the subscriber
+	time.Sleep(100 * time.Millisecond)
+
+	createRequest := &upvoteSystem.CreateCryptoRequest{
+		Crypto: &upvoteSystem.Cryptocurrency{
+			Name:        "Bitcoin",
+			Description: "The most valuable cryptocurrency",
+		},
+	}
+	bitcoin, err := grpcServer.CreateCrypto(context.Background(), createRequest)
+
+	require.Nil(t, err)
+
+	createRequest = &upvoteSystem.CreateCryptoRequest{
+		Crypto: &upvoteSystem.Cryptocurrency{
+			Name:        "Ethereum",
+			Description: "Smart contracts platform",
+		},
+	}
+	ethereum, err := grpcServer.CreateCrypto(context.Background(), createRequest)
+
+	require.Nil(t, err)
+
+	// Test watching a list of cryptocurrencies only receives their changes
+	bitcoinStream, err := client.WatchCryptos(ctx, &upvoteSystem.WatchCryptosRequest{Ids: []string{bitcoin.GetCrypto().GetId()}})
+
+	require.Nil(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+
+	_, err = grpcServer.UpvoteCrypto(context.Background(), &upvoteSystem.UpvoteCryptoRequest{Id: ethereum.GetCrypto().GetId(), VoterId: "voter"})
+
+	require.Nil(t, err)
+
+	_, err = grpcServer.UpdateCrypto(context.Background(), &upvoteSystem.UpdateCryptoRequest{
+		Crypto: &upvoteSystem.Cryptocurrency{
+			Id:          bitcoin.GetCrypto().GetId(),
+			Name:        "Bitcoin",
+			Description: "The first cryptocurrency",
+		},
+	})
+
+	require.Nil(t, err)
+
+	_, err = grpcServer.UpvoteCrypto(context.Background(), &upvoteSystem.UpvoteCryptoRequest{Id: bitcoin.GetCrypto().GetId(), VoterId: "voter"})
+
+	require.Nil(t, err)
+
+	_, err = grpcServer.DeleteCrypto(context.Background(), &upvoteSystem.DeleteCryptoRequest{Id: bitcoin.GetCrypto().GetId()})
+
+	require.Nil(t, err)
+
+	expected := []struct {
+		eventType upvoteSystem.CryptoEventType
+		id        string
+		sequence  uint64
+	}{
+		{upvoteSystem.CryptoEventType_EVENT_CREATED, bitcoin.GetCrypto().GetId(), 1},
+		{upvoteSystem.CryptoEventType_EVENT_CREATED, ethereum.GetCrypto().GetId(), 1},
+		{upvoteSystem.CryptoEventType_EVENT_VOTED, ethereum.GetCrypto().GetId(), 2},
+		{upvoteSystem.CryptoEventType_EVENT_UPDATED, bitcoin.GetCrypto().GetId(), 2},
+		{upvoteSystem.CryptoEventType_EVENT_VOTED, bitcoin.GetCrypto().GetId(), 3},
+		{upvoteSystem.CryptoEventType_EVENT_DELETED, bitcoin.GetCrypto().GetId(), 4},
+	}
+
+	for _, event := range expected {
+		resp, err := allStream.Recv()
+
+		require.Nil(t, err)
+
+		assert.Equal(t, event.eventType, resp.GetType())
+		assert.Equal(t, event.id, resp.GetCrypto().GetId())
+		assert.Equal(t, event.sequence, resp.GetSequence())
+	}
+
+	for _, event := range expected[3:] {
+		resp, err := bitcoinStream.Recv()
+
+		require.Nil(t, err)
+
+		assert.Equal(t, event.eventType, resp.GetType())
+		assert.Equal(t, event.sequence, resp.GetSequence())
+		if event.eventType == upvoteSystem.CryptoEventType_EVENT_UPDATED {
+			assert.Equal(t, "The first cryptocurrency", resp.GetCrypto().GetDescription())
+		}
+	}
+}
+
+// leaderboardNames - Names and ranks of a leaderboard as "rank name"
+func leaderboardNames(entries []*upvoteSystem.LeaderboardEntry) []string {
+	var result []string
+	for _, entry := range entries {
+		result = append(result, fmt.Sprintf("%d %s", entry.GetRank(), entry.GetCrypto().GetName()))
+	}
+	return result
+}
+
+func TestGetLeaderboard(t *testing.T) {
+	grpcServer := newServer(setupDB())
+	defer clearDB()
+
+	ids := map[string]string{}
+	for _, name := range []string{"Bitcoin", "Ethereum", "Litecoin"} {
+		createRequest := &upvoteSystem.CreateCryptoRequest{
+			Crypto: &upvoteSystem.Cryptocurrency{Name: name, Description: name},
+		}
+		created, err := grpcServer.CreateCrypto(context.Background(), createRequest)
+
+		require.Nil(t, err)
+
+		ids[name] = created.GetCrypto().GetId()
+	}
+
+	for _, voterID := range []string{"alice", "bob"} {
+		_, err := grpcServer.UpvoteCrypto(context.Background(), &upvoteSystem.UpvoteCryptoRequest{Id: ids["Bitcoin"], VoterId: voterID})
+
+		require.Nil(t, err)
+	}
+	_, err := grpcServer.UpvoteCrypto(context.Background(), &upvoteSystem.UpvoteCryptoRequest{Id: ids["Ethereum"], VoterId: "alice"})
+
+	require.Nil(t, err)
+
+	_, err = grpcServer.DownvoteCrypto(context.Background(), &upvoteSystem.DownvoteCryptoRequest{Id: ids["Litecoin"], VoterId: "alice"})
+
+	require.Nil(t, err)
+
+	// Test invalid requests
+	_, err = grpcServer.GetLeaderboard(context.Background(), &upvoteSystem.GetLeaderboardRequest{Metric: upvoteSystem.LeaderboardMetric(9)})
+
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = Invalid metric", err.Error())
+
+	_, err = grpcServer.GetLeaderboard(context.Background(), &upvoteSystem.GetLeaderboardRequest{Limit: maxLeaderboardSize + 1})
+
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = Invalid limit", err.Error())
+
+	// Test the default board ranks by net votes
+	resp, err := grpcServer.GetLeaderboard(context.Background(), &upvoteSystem.GetLeaderboardRequest{})
+
+	require.Nil(t, err)
+
+	assert.Equal(t, []string{"1 Bitcoin", "2 Ethereum", "3 Litecoin"}, leaderboardNames(resp.GetEntries()))
+	assert.Equal(t, []float64{2, 1, -1}, []float64{resp.GetEntries()[0].GetScore(), resp.GetEntries()[1].GetScore(), resp.GetEntries()[2].GetScore()})
+
+	// Test the limit
+	resp, err = grpcServer.GetLeaderboard(context.Background(), &upvoteSystem.GetLeaderboardRequest{Limit: 2})
+
+	require.Nil(t, err)
+
+	assert.Equal(t, []string{"1 Bitcoin", "2 Ethereum"}, leaderboardNames(resp.GetEntries()))
+
+	// Test equal scores share a rank
+	resp, err = grpcServer.GetLeaderboard(context.Background(), &upvoteSystem.GetLeaderboardRequest{Metric: upvoteSystem.LeaderboardMetric_METRIC_DOWNVOTES})
+
+	require.Nil(t, err)
+
+	assert.Equal(t, []string{"1 Litecoin", "2 Ethereum", "2 Bitcoin"}, leaderboardNames(resp.GetEntries()))
+
+	// Test computed rankings
+	resp, err = grpcServer.GetLeaderboard(context.Background(), &upvoteSystem.GetLeaderboardRequest{Metric: upvoteSystem.LeaderboardMetric_METRIC_WILSON})
+
+	require.Nil(t, err)
+
+	assert.Equal(t, []string{"1 Bitcoin", "2 Ethereum", "3 Litecoin"}, leaderboardNames(resp.GetEntries()))
+	assert.InDelta(t, 0.3424, resp.GetEntries()[0].GetScore(), 0.0001)
+}
+
+func TestWatchLeaderboard(t *testing.T) {
+	grpcServer := newServer(setupDB())
+	defer clearDB()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithInsecure(), grpc.WithContextDialer(dialer(grpcServer)))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := upvoteSystem.NewUpvoteSystemClient(conn)
+
+	create := func(name string) string {
+		created, err := grpcServer.CreateCrypto(context.Background(), &upvoteSystem.CreateCryptoRequest{
+			Crypto: &upvoteSystem.Cryptocurrency{Name: name, Description: name},
+		})
+
+		require.Nil(t, err)
+
+		return created.GetCrypto().GetId()
+	}
+	bitcoin := create("Bitcoin")
+	ethereum := create("Ethereum")
+
+	// Test invalid request
+	stream, err := client.WatchLeaderboard(ctx, &upvoteSystem.GetLeaderboardRequest{Limit: -1})
+
+	require.Nil(t, err)
+
+	_, err = stream.Recv()
+
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = Invalid limit", err.Error())
+
+	// Test the board is sent first
+	stream, err = client.WatchLeaderboard(ctx, &upvoteSystem.GetLeaderboardRequest{Limit: 2})
+
+	require.Nil(t, err)
+
+	resp, err := stream.Recv()
+
+	require.Nil(t, err)
+
+	assert.Equal(t, []string{"1 Ethereum", "1 Bitcoin"}, leaderboardNames(resp.GetEntries()))
+	assert.Empty(t, resp.GetChanges())
+
+	// Test a vote reordering the board sends the rank changes
+	_, err = grpcServer.UpvoteCrypto(context.Background(), &upvoteSystem.UpvoteCryptoRequest{Id: ethereum, VoterId: "alice"})
+
+	require.Nil(t, err)
+
+	resp, err = stream.Recv()
+
+	require.Nil(t, err)
+
+	assert.Equal(t, []string{"1 Ethereum", "2 Bitcoin"}, leaderboardNames(resp.GetEntries()))
+	require.Len(t, resp.GetChanges(), 1)
+	assert.Equal(t, bitcoin, resp.GetChanges()[0].GetId())
+	assert.Equal(t, uint32(1), resp.GetChanges()[0].GetPreviousRank())
+	assert.Equal(t, uint32(2), resp.GetChanges()[0].GetRank())
+
+	// Test cryptocurrencies entering and leaving the board
+	litecoin := create("Litecoin")
+
+	resp, err = stream.Recv()
+
+	require.Nil(t, err)
+
+	assert.Equal(t, []string{"1 Ethereum", "2 Litecoin"}, leaderboardNames(resp.GetEntries()))
+	require.Len(t, resp.GetChanges(), 2)
+	assert.Equal(t, &upvoteSystem.RankChange{Id: litecoin, PreviousRank: 0, Rank: 2}, resp.GetChanges()[0])
+	assert.Equal(t, &upvoteSystem.RankChange{Id: bitcoin, PreviousRank: 2, Rank: 0}, resp.GetChanges()[1])
+
+	// Test renames and votes keeping the order send nothing
+	_, err = grpcServer.UpdateCrypto(context.Background(), &upvoteSystem.UpdateCryptoRequest{
+		Crypto: &upvoteSystem.Cryptocurrency{Id: ethereum, Name: "Ether", Description: "Ethereum"},
+	})
+
+	require.Nil(t, err)
+
+	_, err = grpcServer.UpvoteCrypto(context.Background(), &upvoteSystem.UpvoteCryptoRequest{Id: ethereum, VoterId: "bob"})
+
+	require.Nil(t, err)
+
+	_, err = grpcServer.UpvoteCrypto(context.Background(), &upvoteSystem.UpvoteCryptoRequest{Id: bitcoin, VoterId: "alice"})
+
+	require.Nil(t, err)
+
+	resp, err = stream.Recv()
+
+	require.Nil(t, err)
+
+	assert.Equal(t, []string{"1 Ether", "2 Bitcoin"}, leaderboardNames(resp.GetEntries()))
+	require.Len(t, resp.GetChanges(), 2)
+	assert.Equal(t, &upvoteSystem.RankChange{Id: bitcoin, PreviousRank: 0, Rank: 2}, resp.GetChanges()[0])
+	assert.Equal(t, &upvoteSystem.RankChange{Id: litecoin, PreviousRank: 2, Rank: 0}, resp.GetChanges()[1])
+}
+
+func TestSearchCrypto(t *testing.T) {
+	grpcServer := newServer(setupDB())
+	defer clearDB()
+
+	for _, crypto := range []*upvoteSystem.Cryptocurrency{
+		{Name: "Bitcoin", Description: "The first cryptocurrency"},
+		{Name: "Bitcoin Cash", Description: "Bitcoin fork with bigger blocks"},
+		{Name: "Ethereum", Description: "Smart contracts platform"},
+	} {
+		_, err := grpcServer.CreateCrypto(context.Background(), &upvoteSystem.CreateCryptoRequest{Crypto: crypto})
+
+		require.Nil(t, err)
+	}
+
+	// Test invalid requests
+	_, err := grpcServer.SearchCrypto(context.Background(), &upvoteSystem.SearchCryptoRequest{Query: " ? "})
+
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = Invalid query", err.Error())
+
+	_, err = grpcServer.SearchCrypto(context.Background(), &upvoteSystem.SearchCryptoRequest{Query: "bitcoin", Limit: maxSearchLimit + 1})
+
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = Invalid limit", err.Error())
+
+	// Test results are ranked by relevance
+	resp, err := grpcServer.SearchCrypto(context.Background(), &upvoteSystem.SearchCryptoRequest{Query: "BITCOIN cash"})
+
+	require.Nil(t, err)
+
+	var names []string
+	for _, result := range resp.GetResults() {
+		names = append(names, result.GetCrypto().GetName())
+	}
+	assert.Equal(t, []string{"Bitcoin Cash", "Bitcoin"}, names)
+	assert.Greater(t, resp.GetResults()[0].GetRelevance(), resp.GetResults()[1].GetRelevance())
+
+	// Test prefix and fuzzy matching
+	resp, err = grpcServer.SearchCrypto(context.Background(), &upvoteSystem.SearchCryptoRequest{Query: "eth"})
+
+	require.Nil(t, err)
+	require.Len(t, resp.GetResults(), 1)
+	assert.Equal(t, "Ethereum", resp.GetResults()[0].GetCrypto().GetName())
+
+	resp, err = grpcServer.SearchCrypto(context.Background(), &upvoteSystem.SearchCryptoRequest{Query: "contrats", Limit: 1})
+
+	require.Nil(t, err)
+	require.Len(t, resp.GetResults(), 1)
+	assert.Equal(t, "Ethereum", resp.GetResults()[0].GetCrypto().GetName())
+
+	// Test no match
+	resp, err = grpcServer.SearchCrypto(context.Background(), &upvoteSystem.SearchCryptoRequest{Query: "dogecoin"})
+
+	require.Nil(t, err)
+	assert.Empty(t, resp.GetResults())
+}
+
+func TestRetractVote(t *testing.T) {
+	grpcServer := newServer(setupDB())
+	defer clearDB()
+
+	// Test request with empty ID
+	emptyIDRequest := &upvoteSystem.RetractVoteRequest{
+		Id: "",
+	}
+	_, err := grpcServer.RetractVote(context.Background(), emptyIDRequest)
+
+	require.NotNil(t, err)
+
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = the provided hex string is not a valid ObjectID", err.Error())
+
+	// Test request with valid ID but not found on DB
+	NotFoundIDRequest := &upvoteSystem.RetractVoteRequest{
+		Id:      primitive.NewObjectID().Hex(),
+		VoterId: "voter",
+	}
+	_, err = grpcServer.RetractVote(context.Background(), NotFoundIDRequest)
+
+	require.NotNil(t, err)
+
+	assert.Equal(t, "rpc error: code = NotFound desc = Couldn`t find Cryptocurrency with Object Id", err.Error())
+
+	createRequest := &upvoteSystem.CreateCryptoRequest{
+		Crypto: &upvoteSystem.Cryptocurrency{
+			Name:        "Bitcoin",
+			Description: "The most valuable cryptocurrency",
+		},
+	}
+
+	cryptoResponse, err := grpcServer.CreateCrypto(context.Background(), createRequest)
+
+	require.Nil(t, err)
+
+	// Test request from a voter without vote
+	validRequest := &upvoteSystem.RetractVoteRequest{
+		Id:      cryptoResponse.GetCrypto().GetId(),
+		VoterId: "voter",
+	}
+	_, err = grpcServer.RetractVote(context.Background(), validRequest)
+
+	require.NotNil(t, err)
+
+	assert.Equal(t, "rpc error: code = NotFound desc = Couldn`t find vote of this voter on the Cryptocurrency", err.Error())
+
+	// Test with valid request, listeners receive the corrected sum
+	upvoteRequest := &upvoteSystem.UpvoteCryptoRequest{
+		Id:      cryptoResponse.GetCrypto().GetId(),
+		VoterId: "voter",
+	}
+	_, err = grpcServer.UpvoteCrypto(context.Background(), upvoteRequest)
+
+	require.Nil(t, err)
+
+	cryptoID, err := primitive.ObjectIDFromHex(cryptoResponse.GetCrypto().GetId())
+
+	require.Nil(t, err)
+
+	sub, _ := grpcServer.hub.subscribe(cryptoID)
+	defer grpcServer.hub.unsubscribe(sub)
+
+	response, err := grpcServer.RetractVote(context.Background(), validRequest)
+
+	require.Nil(t, err)
+
+	assert.Equal(t, int32(0), response.GetCrypto().GetUpvote())
+	assert.Equal(t, int32(0), response.GetCrypto().GetDownvote())
+
+	broadcasted := (<-sub.updates).crypto
+	assert.Equal(t, int32(0), broadcasted.Upvote-broadcasted.Downvote)
+}
+
+func TestChangeVote(t *testing.T) {
+	grpcServer := newServer(setupDB())
+	defer clearDB()
+
+	// Test request with empty ID
+	emptyIDRequest := &upvoteSystem.ChangeVoteRequest{
+		Id: "",
+	}
+	_, err := grpcServer.ChangeVote(context.Background(), emptyIDRequest)
+
+	require.NotNil(t, err)
+
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = the provided hex string is not a valid ObjectID", err.Error())
+
+	createRequest := &upvoteSystem.CreateCryptoRequest{
+		Crypto: &upvoteSystem.Cryptocurrency{
+			Name:        "Bitcoin",
+			Description: "The most valuable cryptocurrency",
+		},
+	}
+
+	cryptoResponse, err := grpcServer.CreateCrypto(context.Background(), createRequest)
+
+	require.Nil(t, err)
+
+	// Test request without direction
+	noDirectionRequest := &upvoteSystem.ChangeVoteRequest{
+		Id:      cryptoResponse.GetCrypto().GetId(),
+		VoterId: "voter",
+	}
+	_, err = grpcServer.ChangeVote(context.Background(), noDirectionRequest)
+
+	require.NotNil(t, err)
+
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = Invalid vote direction", err.Error())
+
+	// Test request from a voter without vote
+	validRequest := &upvoteSystem.ChangeVoteRequest{
+		Id:        cryptoResponse.GetCrypto().GetId(),
+		VoterId:   "voter",
+		Direction: upvoteSystem.VoteDirection_VOTE_DOWN,
+	}
+	_, err = grpcServer.ChangeVote(context.Background(), validRequest)
+
+	require.NotNil(t, err)
+
+	assert.Equal(t, "rpc error: code = NotFound desc = Couldn`t find vote of this voter on the Cryptocurrency", err.Error())
+
+	// Test with valid request
+	upvoteRequest := &upvoteSystem.UpvoteCryptoRequest{
+		Id:      cryptoResponse.GetCrypto().GetId(),
+		VoterId: "voter",
+	}
+	_, err = grpcServer.UpvoteCrypto(context.Background(), upvoteRequest)
+
+	require.Nil(t, err)
+
+	response, err := grpcServer.ChangeVote(context.Background(), validRequest)
+
+	require.Nil(t, err)
+
+	assert.Equal(t, int32(0), response.GetCrypto().GetUpvote())
+	assert.Equal(t, int32(1), response.GetCrypto().GetDownvote())
+
+	// Test changing to the current direction
+	_, err = grpcServer.ChangeVote(context.Background(), validRequest)
+
+	require.NotNil(t, err)
+
+	assert.Equal(t, "rpc error: code = AlreadyExists desc = Voter already voted on this Cryptocurrency", err.Error())
+}
+
+func TestGetVoteHistory(t *testing.T) {
+	grpcServer := newServer(setupDB())
+	defer clearDB()
+
+	// Test request with empty ID
+	emptyIDRequest := &upvoteSystem.GetVoteHistoryRequest{
+		Id: "",
+	}
+	_, err := grpcServer.GetVoteHistory(context.Background(), emptyIDRequest)
+
+	require.NotNil(t, err)
+
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = the provided hex string is not a valid ObjectID", err.Error())
+
+	cryptoID := primitive.NewObjectID()
+	start := time.Date(2021, 2, 25, 10, 0, 0, 0, time.UTC)
+
+	// Snapshots every 30 minutes during two days
+	var snapshots []model.Snapshot
+	for i := int32(0); i < 96; i++ {
+		snapshots = append(snapshots, model.Snapshot{
+			CryptoID:  cryptoID,
+			Upvote:    i,
+			Downvote:  1,
+			Sum:       i - 1,
+			Timestamp: start.Add(time.Duration(i) * 30 * time.Minute),
+		})
+	}
+	require.Nil(t, grpcServer.snapshots.SaveSnapshots(context.Background(), snapshots))
+
+	// Test request with inverted range
+	invalidRangeRequest := &upvoteSystem.GetVoteHistoryRequest{
+		Id:   cryptoID.Hex(),
+		From: timestamppb.New(start.Add(time.Hour)),
+		To:   timestamppb.New(start),
+	}
+	_, err = grpcServer.GetVoteHistory(context.Background(), invalidRangeRequest)
+
+	require.NotNil(t, err)
+
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = Invalid time range", err.Error())
+
+	// Test raw snapshots within range
+	rawRequest := &upvoteSystem.GetVoteHistoryRequest{
+		Id:   cryptoID.Hex(),
+		From: timestamppb.New(start.Add(time.Hour)),
+		To:   timestamppb.New(start.Add(3 * time.Hour)),
+	}
+	response, err := grpcServer.GetVoteHistory(context.Background(), rawRequest)
+
+	require.Nil(t, err)
+	require.Len(t, response.GetSnapshots(), 5)
+
+	assert.Equal(t, start.Add(time.Hour), response.GetSnapshots()[0].GetTimestamp().AsTime())
+	assert.Equal(t, int32(2), response.GetSnapshots()[0].GetUpvote())
+	assert.Equal(t, int32(5), response.GetSnapshots()[4].GetSum())
+
+	// Test hourly buckets keep the last snapshot of each hour
+	rawRequest.Resolution = upvoteSystem.HistoryResolution_RESOLUTION_HOURLY
+	response, err = grpcServer.GetVoteHistory(context.Background(), rawRequest)
+
+	require.Nil(t, err)
+	require.Len(t, response.GetSnapshots(), 3)
+
+	assert.Equal(t, start.Add(time.Hour), response.GetSnapshots()[0].GetTimestamp().AsTime())
+	assert.Equal(t, int32(3), response.GetSnapshots()[0].GetUpvote())
+
+	// Test daily buckets over the whole history
+	dailyRequest := &upvoteSystem.GetVoteHistoryRequest{
+		Id:         cryptoID.Hex(),
+		Resolution: upvoteSystem.HistoryResolution_RESOLUTION_DAILY,
+	}
+	response, err = grpcServer.GetVoteHistory(context.Background(), dailyRequest)
+
+	require.Nil(t, err)
+	require.Len(t, response.GetSnapshots(), 3)
 
+	assert.Equal(t, time.Date(2021, 2, 25, 0, 0, 0, 0, time.UTC), response.GetSnapshots()[0].GetTimestamp().AsTime())
+	assert.Equal(t, int32(27), response.GetSnapshots()[0].GetUpvote())
+	assert.Equal(t, int32(95), response.GetSnapshots()[2].GetUpvote())
 }
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryRepository - Concurrency-safe CryptoRepository kept in process memory
+type memoryRepository struct {
+	mutex  sync.RWMutex
+	crypto map[primitive.ObjectID]model.Crypto
+	votes  map[memoryVoteKey]model.Vote
+	// snapshots of each cryptocurrency sorted by timestamp
+	snapshots    map[primitive.ObjectID][]model.Snapshot
+	lastSnapshot time.Time
+	// nonces - Expiry of each used nonce
+	nonces       map[memoryNonceKey]time.Time
+	nonceSweeper sweeper
+}
+
+type memoryVoteKey struct {
+	cryptoID primitive.ObjectID
+	voterID  string
+}
+
+type memoryNonceKey struct {
+	key   string
+	nonce string
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{
+		crypto:    make(map[primitive.ObjectID]model.Crypto),
+		votes:     make(map[memoryVoteKey]model.Vote),
+		snapshots: make(map[primitive.ObjectID][]model.Snapshot),
+		nonces:    make(map[memoryNonceKey]time.Time),
+	}
+}
+
+func (r *memoryRepository) Create(ctx context.Context, crypto model.Crypto) (model.Crypto, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.conflicts(crypto) {
+		return model.Crypto{}, ErrAlreadyExists
+	}
+
+	if crypto.ID.IsZero() {
+		crypto.ID = primitive.NewObjectID()
+	}
+	r.crypto[crypto.ID] = crypto
+	return crypto, nil
+}
+
+func (r *memoryRepository) GetByID(ctx context.Context, id primitive.ObjectID) (model.Crypto, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	crypto, found := r.crypto[id]
+	if !found {
+		return model.Crypto{}, ErrNotFound
+	}
+	return crypto, nil
+}
+
+// conflicts - Reports whether another stored cryptocurrency has the name or symbol of crypto, ignoring case
+func (r *memoryRepository) conflicts(crypto model.Crypto) bool {
+	for _, stored := range r.crypto {
+		if stored.ID == crypto.ID {
+			continue
+		}
+		if uniqueKey(stored.Name) == uniqueKey(crypto.Name) {
+			return true
+		}
+		if crypto.Symbol != "" && uniqueKey(stored.Symbol) == uniqueKey(crypto.Symbol) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *memoryRepository) GetBySymbol(ctx context.Context, symbol string) (model.Crypto, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, crypto := range r.crypto {
+		if crypto.Symbol != "" && uniqueKey(crypto.Symbol) == uniqueKey(symbol) {
+			return crypto, nil
+		}
+	}
+	return model.Crypto{}, ErrNotFound
+}
+
+func (r *memoryRepository) List(ctx context.Context, query ListQuery) ([]model.Crypto, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var result []model.Crypto
+	for _, crypto := range r.crypto {
+		result = append(result, crypto)
+	}
+	return query.apply(result), nil
+}
+
+func (r *memoryRepository) Update(ctx context.Context, crypto model.Crypto) (model.Crypto, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	stored, found := r.crypto[crypto.ID]
+	if !found {
+		return model.Crypto{}, ErrNotFound
+	}
+	if r.conflicts(crypto) {
+		return model.Crypto{}, ErrAlreadyExists
+	}
+
+	stored = withDetails(stored, crypto)
+	r.crypto[crypto.ID] = stored
+	return stored, nil
+}
+
+func (r *memoryRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, found := r.crypto[id]; !found {
+		return ErrNotFound
+	}
+	delete(r.crypto, id)
+	for key := range r.votes {
+		if key.cryptoID == id {
+			delete(r.votes, key)
+		}
+	}
+	return nil
+}
+
+func (r *memoryRepository) UpdateVote(ctx context.Context, id primitive.ObjectID, voterID string, decide VoteDecision) (model.Crypto, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	crypto, found := r.crypto[id]
+	if !found {
+		return model.Crypto{}, ErrNotFound
+	}
+
+	key := memoryVoteKey{cryptoID: id, voterID: voterID}
+	previous := r.votes[key].Direction
+
+	next, err := decide(previous)
+	if err != nil {
+		return model.Crypto{}, err
+	}
+	if next == previous {
+		return crypto, nil
+	}
+
+	now := time.Now()
+	if next == model.NoVote {
+		delete(r.votes, key)
+	} else {
+		r.votes[key] = model.Vote{CryptoID: id, VoterID: voterID, Direction: next, UpdatedAt: now}
+	}
+
+	upvote, downvote := voteDelta(previous, next)
+	crypto.Upvote += upvote
+	crypto.Downvote += downvote
+	crypto.LastVoteAt = now
+	r.crypto[id] = crypto
+	return crypto, nil
+}
+
+func (r *memoryRepository) SaveSnapshots(ctx context.Context, snapshots []model.Snapshot) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, snapshot := range snapshots {
+		history := r.snapshots[snapshot.CryptoID]
+
+		i := sort.Search(len(history), func(i int) bool {
+			return !history[i].Timestamp.Before(snapshot.Timestamp)
+		})
+		if i < len(history) && history[i].Timestamp.Equal(snapshot.Timestamp) {
+			continue
+		}
+
+		history = append(history, model.Snapshot{})
+		copy(history[i+1:], history[i:])
+		history[i] = snapshot
+		r.snapshots[snapshot.CryptoID] = history
+
+		if snapshot.Timestamp.After(r.lastSnapshot) {
+			r.lastSnapshot = snapshot.Timestamp
+		}
+	}
+	return nil
+}
+
+func (r *memoryRepository) LastSnapshotTime(ctx context.Context) (time.Time, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.lastSnapshot, nil
+}
+
+func (r *memoryRepository) ListSnapshots(ctx context.Context, id primitive.ObjectID, from time.Time, to time.Time) ([]model.Snapshot, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var result []model.Snapshot
+	for _, snapshot := range r.snapshots[id] {
+		if snapshot.Timestamp.Before(from) || snapshot.Timestamp.After(to) {
+			continue
+		}
+		result = append(result, snapshot)
+	}
+	return result, nil
+}
+
+func (r *memoryRepository) UseNonce(ctx context.Context, key string, nonce string, expires time.Time) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+	if r.nonceSweeper.due(now) {
+		for nonceKey, nonceExpires := range r.nonces {
+			if nonceExpires.Before(now) {
+				delete(r.nonces, nonceKey)
+			}
+		}
+	}
+
+	nonceKey := memoryNonceKey{key: key, nonce: nonce}
+	if used, found := r.nonces[nonceKey]; found && !used.Before(now) {
+		return ErrNonceUsed
+	}
+	r.nonces[nonceKey] = expires
+	return nil
+}
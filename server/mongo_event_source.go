@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// tokenSaveInterval - Minimum time between two resume token writes while events keep coming
+	tokenSaveInterval = time.Second
+	// changeStreamRetryDelay - Wait before reopening a failed change stream
+	changeStreamRetryDelay = time.Second
+)
+
+// Server error codes of a resume token the change stream can't continue from
+const (
+	changeStreamFatalErrorCode  = 280
+	changeStreamHistoryLostCode = 286
+	invalidResumeTokenErrorCode = 260
+)
+
+// mongoEventSource - Feeds the hub from a change stream on the Cryptocurrency collection, so every
+// replica sharing the database streams the changes stored by any of them. Requires a replica set.
+// The resume token of each replica is saved in the ChangeStreamToken collection so a restarted
+// replica continues where it stopped
+type mongoEventSource struct {
+	collection *mongo.Collection
+	tokens     *mongo.Collection
+	hub        *hub
+	replicaID  string
+}
+
+// resumeToken - Last change stream position of a replica
+type resumeToken struct {
+	ReplicaID string    `bson:"_id"`
+	Token     bson.Raw  `bson:"token"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+// changeDocument - Fields of a change stream event used to build hub events
+type changeDocument struct {
+	OperationType string `bson:"operationType"`
+	DocumentKey   struct {
+		ID primitive.ObjectID `bson:"_id"`
+	} `bson:"documentKey"`
+	FullDocument      *model.Crypto `bson:"fullDocument"`
+	UpdateDescription struct {
+		UpdatedFields bson.M `bson:"updatedFields"`
+	} `bson:"updateDescription"`
+}
+
+func newMongoEventSource(repository *mongoRepository, h *hub, replicaID string) *mongoEventSource {
+	return &mongoEventSource{
+		collection: repository.collection,
+		tokens:     repository.collection.Database().Collection("ChangeStreamToken"),
+		hub:        h,
+		replicaID:  replicaID,
+	}
+}
+
+// Publish does nothing, the change reaches the hub through the change stream
+func (s *mongoEventSource) Publish(kind eventType, crypto model.Crypto) {}
+
+// Run watches the collection until ctx is done, reopening the change stream when it fails
+func (s *mongoEventSource) Run(ctx context.Context) error {
+	for {
+		err := s.watch(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if isStaleResumeTokenError(err) {
+			log.Printf("Change stream resume token of %s is no longer valid, changes since it are lost", s.replicaID)
+			if _, err := s.tokens.DeleteOne(ctx, bson.M{"_id": s.replicaID}); err != nil {
+				log.Printf("Failed to delete change stream resume token: %v", err)
+			}
+		} else {
+			log.Printf("Change stream failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(changeStreamRetryDelay):
+		}
+	}
+}
+
+// watch - Publishes the changes after the saved resume token until the stream fails or ctx is done
+func (s *mongoEventSource) watch(ctx context.Context) error {
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+
+	saved := resumeToken{}
+	err := s.tokens.FindOne(ctx, bson.M{"_id": s.replicaID}).Decode(&saved)
+	switch {
+	case err == nil:
+		opts.SetResumeAfter(saved.Token)
+	case err != mongo.ErrNoDocuments:
+		return err
+	}
+
+	stream, err := s.collection.Watch(ctx, mongo.Pipeline{}, opts)
+	if err != nil {
+		return err
+	}
+	defer stream.Close(context.Background())
+
+	var lastSave time.Time
+	defer func() {
+		if token := stream.ResumeToken(); token != nil {
+			s.saveToken(context.Background(), token)
+		}
+	}()
+
+	for stream.Next(ctx) {
+		change := changeDocument{}
+		if err := stream.Decode(&change); err != nil {
+			return err
+		}
+
+		if kind, crypto, ok := change.event(); ok {
+			s.hub.publish(kind, crypto)
+		}
+
+		if time.Since(lastSave) >= tokenSaveInterval {
+			s.saveToken(ctx, stream.ResumeToken())
+			lastSave = time.Now()
+		}
+	}
+	return stream.Err()
+}
+
+func (s *mongoEventSource) saveToken(ctx context.Context, token bson.Raw) {
+	_, err := s.tokens.ReplaceOne(ctx,
+		bson.M{"_id": s.replicaID},
+		resumeToken{ReplicaID: s.replicaID, Token: token, UpdatedAt: time.Now()},
+		options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		log.Printf("Failed to save change stream resume token: %v", err)
+	}
+}
+
+// event - Converts the change to a hub event, ok is false for changes streams don't report, like
+// updates of a document deleted before its lookup
+func (c changeDocument) event() (kind eventType, crypto model.Crypto, ok bool) {
+	switch c.OperationType {
+	case "insert":
+		kind = eventCreated
+	case "replace":
+		kind = eventUpdated
+	case "update":
+		kind = eventUpdated
+		for _, field := range []string{"upvote", "downvote"} {
+			if _, found := c.UpdateDescription.UpdatedFields[field]; found {
+				kind = eventVoted
+			}
+		}
+	case "delete":
+		return eventDeleted, model.Crypto{ID: c.DocumentKey.ID}, true
+	default:
+		return 0, model.Crypto{}, false
+	}
+
+	if c.FullDocument == nil {
+		return 0, model.Crypto{}, false
+	}
+	return kind, *c.FullDocument, true
+}
+
+func isStaleResumeTokenError(err error) bool {
+	if commandError, ok := err.(mongo.CommandError); ok {
+		switch commandError.Code {
+		case changeStreamHistoryLostCode, changeStreamFatalErrorCode, invalidResumeTokenErrorCode:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// mongoChangeStreamsUnsupported - Server error of change streams on a standalone MongoDB
+const mongoChangeStreamsUnsupported = 40573
+
+func TestChangeDocumentEvent(t *testing.T) {
+	id := primitive.NewObjectID()
+	crypto := &model.Crypto{ID: id, Name: "Bitcoin", Upvote: 2}
+
+	change := changeDocument{OperationType: "insert", FullDocument: crypto}
+	kind, result, ok := change.event()
+
+	assert.True(t, ok)
+	assert.Equal(t, eventCreated, kind)
+	assert.Equal(t, *crypto, result)
+
+	// Test updates of the counters are votes
+	change = changeDocument{OperationType: "update", FullDocument: crypto}
+	change.UpdateDescription.UpdatedFields = bson.M{"downvote": 1}
+	kind, _, ok = change.event()
+
+	assert.True(t, ok)
+	assert.Equal(t, eventVoted, kind)
+
+	change.UpdateDescription.UpdatedFields = bson.M{"name": "Bitcoin", "description": "The first cryptocurrency"}
+	kind, _, ok = change.event()
+
+	assert.True(t, ok)
+	assert.Equal(t, eventUpdated, kind)
+
+	// Test updates of a document deleted before the lookup are skipped
+	change.FullDocument = nil
+	_, _, ok = change.event()
+
+	assert.False(t, ok)
+
+	// Test deletions only carry the id
+	change = changeDocument{OperationType: "delete"}
+	change.DocumentKey.ID = id
+	kind, result, ok = change.event()
+
+	assert.True(t, ok)
+	assert.Equal(t, eventDeleted, kind)
+	assert.Equal(t, model.Crypto{ID: id}, result)
+
+	_, _, ok = changeDocument{OperationType: "drop"}.event()
+
+	assert.False(t, ok)
+}
+
+func TestOpenEventSource(t *testing.T) {
+	h := newHub(defaultStreamBufferSize, coalesceLatest)
+
+	source, err := openEventSource(localEventSourceName, newMemoryRepository(), h, "replica")
+
+	require.Nil(t, err)
+	assert.Equal(t, localEventSource{hub: h}, source)
+
+	_, err = openEventSource(mongoEventSourceName, newMemoryRepository(), h, "replica")
+
+	assert.Equal(t, "the mongo event source requires the mongo storage backend", err.Error())
+
+	_, err = openEventSource("redis", newMemoryRepository(), h, "replica")
+
+	assert.NotNil(t, err)
+}
+
+func TestMongoEventSource(t *testing.T) {
+	storage := setupDB()
+	defer clearDB()
+
+	repository, ok := storage.(*mongoRepository)
+	if !ok {
+		t.Skip("requires STORAGE_BACKEND=mongo")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	probe, err := repository.collection.Watch(ctx, mongo.Pipeline{})
+	if commandError, ok := err.(mongo.CommandError); ok && commandError.Code == mongoChangeStreamsUnsupported {
+		t.Skip("change streams require a MongoDB replica set")
+	}
+	require.Nil(t, err)
+	probe.Close(ctx)
+
+	h := newHub(defaultStreamBufferSize, coalesceLatest)
+	source := newMongoEventSource(repository, h, "test-replica")
+	sub, _ := h.subscribe()
+	defer h.unsubscribe(sub)
+
+	done := make(chan struct{})
+	go func() {
+		source.Run(ctx)
+		close(done)
+	}()
+
+	// Give the source time to open the change stream
+	time.Sleep(time.Second)
+
+	// Test changes stored by any replica reach the hub
+	crypto, err := repository.Create(ctx, model.Crypto{ID: primitive.NewObjectID(), Name: "Bitcoin", Description: "The most valuable cryptocurrency"})
+	require.Nil(t, err)
+
+	_, err = repository.UpdateVote(ctx, crypto.ID, "voter", func(model.VoteDirection) (model.VoteDirection, error) {
+		return model.Upvote, nil
+	})
+	require.Nil(t, err)
+
+	require.Nil(t, repository.Delete(ctx, crypto.ID))
+
+	for _, expected := range []eventType{eventCreated, eventVoted, eventDeleted} {
+		select {
+		case e := <-sub.updates:
+			assert.Equal(t, expected, e.kind)
+			assert.Equal(t, crypto.ID, e.crypto.ID)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "change stream event not received")
+		}
+	}
+
+	// Test the resume token is saved when the source stops
+	cancel()
+	<-done
+
+	saved := resumeToken{}
+	require.Nil(t, source.tokens.FindOne(context.Background(), bson.M{"_id": "test-replica"}).Decode(&saved))
+	assert.NotEmpty(t, saved.Token)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoRateLimitStore - Buckets in the RateLimit collection, updated atomically by a pipeline so
+// replicas share them. A TTL index deletes them once refilled to capacity
+type mongoRateLimitStore struct {
+	buckets *mongo.Collection
+}
+
+func newMongoRateLimitStore(ctx context.Context, repository *mongoRepository) (*mongoRateLimitStore, error) {
+	buckets := repository.collection.Database().Collection("RateLimit")
+	_, err := buckets.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &mongoRateLimitStore{buckets: buckets}, nil
+}
+
+func (s *mongoRateLimitStore) Take(ctx context.Context, key string, limit rateLimit, now time.Time) (bool, time.Duration, error) {
+	capacity := float64(limit.count)
+	elapsed := bson.M{"$max": bson.A{0, bson.M{"$divide": bson.A{
+		bson.M{"$subtract": bson.A{now, bson.M{"$ifNull": bson.A{"$updated_at", now}}}},
+		1000,
+	}}}}
+	refilled := bson.M{"$min": bson.A{capacity, bson.M{"$add": bson.A{
+		bson.M{"$ifNull": bson.A{"$tokens", capacity}},
+		bson.M{"$multiply": bson.A{elapsed, limit.rate()}},
+	}}}}
+	allowed := bson.M{"$gte": bson.A{"$refilled", 1}}
+
+	// Stages read the fields set by the previous one, new buckets start full
+	pipeline := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"refilled": refilled}}},
+		{{Key: "$set", Value: bson.M{
+			"allowed":    allowed,
+			"tokens":     bson.M{"$cond": bson.A{allowed, bson.M{"$subtract": bson.A{"$refilled", 1}}, "$refilled"}},
+			"updated_at": bson.M{"$max": bson.A{now, bson.M{"$ifNull": bson.A{"$updated_at", now}}}},
+			"expires_at": now.Add(limit.period),
+		}}},
+		{{Key: "$unset", Value: "refilled"}},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var bucket struct {
+		Tokens  float64 `bson:"tokens"`
+		Allowed bool    `bson:"allowed"`
+	}
+	err := s.buckets.FindOneAndUpdate(ctx, bson.M{"_id": key}, pipeline, opts).Decode(&bucket)
+	if isDuplicateKeyError(err) {
+		// Another replica created the bucket first, update it
+		err = s.buckets.FindOneAndUpdate(ctx, bson.M{"_id": key}, pipeline, opts).Decode(&bucket)
+	}
+	if err != nil {
+		return false, 0, err
+	}
+
+	if bucket.Allowed {
+		return true, 0, nil
+	}
+	return false, time.Duration((1 - bucket.Tokens) / limit.rate() * float64(time.Second)), nil
+}
+
+func (s *mongoRateLimitStore) Refund(ctx context.Context, key string, limit rateLimit) error {
+	// Expired buckets are full already, they aren't created again
+	pipeline := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"tokens": bson.M{"$min": bson.A{float64(limit.count), bson.M{"$add": bson.A{"$tokens", 1}}}}}}},
+	}
+	_, err := s.buckets.UpdateOne(ctx, bson.M{"_id": key}, pipeline)
+	return err
+}
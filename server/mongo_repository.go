@@ -0,0 +1,664 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoRepository - CryptoRepository backed by a MongoDB collection
+type mongoRepository struct {
+	collection *mongo.Collection
+	votes      *mongo.Collection
+	snapshots  *mongo.Collection
+	nonces     *mongo.Collection
+}
+
+func newMongoRepository(database *mongo.Database) *mongoRepository {
+	return &mongoRepository{
+		collection: database.Collection("Cryptocurrency"),
+		votes:      database.Collection("Vote"),
+		snapshots:  database.Collection("Snapshot"),
+		nonces:     database.Collection("VoteNonce"),
+	}
+}
+
+// connectMongoRepository - Connects to the MongoDB at uri and uses the Cryptocurrency collection of database
+func connectMongoRepository(ctx context.Context, uri string, database string) (*mongoRepository, error) {
+	dbClient, err := mongo.NewClient(options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dbClient.Connect(ctx); err != nil {
+		return nil, err
+	}
+
+	repository := newMongoRepository(dbClient.Database(database))
+
+	_, err = repository.votes.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "crypto_id", Value: 1}, {Key: "voter_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Names and symbols are unique ignoring case, cryptocurrencies without symbol don't store one
+	uniqueIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "name", Value: 1}},
+			Options: options.Index().SetName("name_unique").SetUnique(true).SetCollation(mongoCaseInsensitive),
+		},
+		{
+			Keys: bson.D{{Key: "symbol", Value: 1}},
+			Options: options.Index().SetName("symbol_unique").SetUnique(true).SetCollation(mongoCaseInsensitive).
+				SetPartialFilterExpression(bson.M{"symbol": bson.M{"$exists": true}}),
+		},
+	}
+	_, err = repository.collection.Indexes().CreateMany(ctx, uniqueIndexes)
+	if isDuplicateKeyError(err) {
+		// Collections written before names were unique ignoring case
+		if err := repository.resolveCaseConflicts(ctx); err != nil {
+			return nil, err
+		}
+		_, err = repository.collection.Indexes().CreateMany(ctx, uniqueIndexes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Collections written before the scores were stored
+	if err := repository.backfillScores(ctx); err != nil {
+		return nil, err
+	}
+
+	// Sort keys of List, net votes are computed and can't be indexed
+	sortIndexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "upvote", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "downvote", Value: 1}, {Key: "_id", Value: 1}}},
+		{
+			Keys:    bson.D{{Key: "name", Value: "text"}, {Key: "description", Value: "text"}},
+			Options: options.Index().SetWeights(bson.M{"name": int(nameWeight), "description": int(descriptionWeight)}),
+		},
+	}
+	for _, score := range storedScores {
+		sortIndexes = append(sortIndexes, mongo.IndexModel{Keys: bson.D{{Key: score.key, Value: 1}, {Key: "_id", Value: 1}}})
+	}
+	_, err = repository.collection.Indexes().CreateMany(ctx, sortIndexes)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = repository.snapshots.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "crypto_id", Value: 1}, {Key: "timestamp", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "timestamp", Value: -1}},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = repository.nonces.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return repository, nil
+}
+
+// resolveCaseConflicts - Renames the cryptocurrencies whose names or symbols only differ in case, so
+// the unique indexes can be built
+func (r *mongoRepository) resolveCaseConflicts(ctx context.Context) error {
+	projection := bson.M{"name": 1, "symbol": 1}
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}).SetProjection(projection))
+	if err != nil {
+		return err
+	}
+
+	var cryptos []model.Crypto
+	if err := cursor.All(ctx, &cryptos); err != nil {
+		return err
+	}
+
+	for _, crypto := range resolveCaseConflicts(cryptos) {
+		update := bson.M{"$set": bson.M{"name": crypto.Name}}
+		if crypto.Symbol == "" {
+			update["$unset"] = bson.M{"symbol": ""}
+		}
+		if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": crypto.ID}, update); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backfillScores - Stores the scores of the cryptocurrencies that have none. A vote in between
+// stores them itself and the backfill leaves that cryptocurrency alone
+func (r *mongoRepository) backfillScores(ctx context.Context) error {
+	unscored := bson.M{storedScores[0].key: bson.M{"$exists": false}}
+	cursor, err := r.collection.Find(ctx, unscored)
+	if err != nil {
+		return err
+	}
+
+	var cryptos []model.Crypto
+	if err := cursor.All(ctx, &cryptos); err != nil {
+		return err
+	}
+
+	for _, crypto := range cryptos {
+		filter := bson.M{"_id": crypto.ID, storedScores[0].key: bson.M{"$exists": false}}
+		if _, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": mongoScores(crypto)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mongoScores - Stored score fields of crypto
+func mongoScores(crypto model.Crypto) bson.M {
+	scores := bson.M{}
+	for _, score := range storedScores {
+		scores[score.key] = rankings[score.field].Score(crypto)
+	}
+	return scores
+}
+
+// mongoCryptoDocument - Cryptocurrency document with its stored scores, decoding it into a
+// model.Crypto skips them
+type mongoCryptoDocument struct {
+	model.Crypto `bson:",inline"`
+	Scores       bson.M `bson:",inline"`
+}
+
+// mongoCaseInsensitive - Collation comparing strings ignoring case, used by the unique indexes
+var mongoCaseInsensitive = &options.Collation{Locale: "en", Strength: 2}
+
+// Create relies on the unique indexes to reject duplicated names and symbols
+func (r *mongoRepository) Create(ctx context.Context, crypto model.Crypto) (model.Crypto, error) {
+	// Scored at the precision of BSON dates, so the scores match the ones List recomputes for cursors
+	crypto.LastVoteAt = crypto.LastVoteAt.Truncate(time.Millisecond)
+	insertResult, err := r.collection.InsertOne(ctx, mongoCryptoDocument{Crypto: crypto, Scores: mongoScores(crypto)})
+	if isDuplicateKeyError(err) {
+		return model.Crypto{}, ErrAlreadyExists
+	}
+	if err != nil {
+		return model.Crypto{}, err
+	}
+
+	crypto.ID = insertResult.InsertedID.(primitive.ObjectID)
+	return crypto, nil
+}
+
+func (r *mongoRepository) GetByID(ctx context.Context, id primitive.ObjectID) (model.Crypto, error) {
+	result := r.collection.FindOne(ctx, bson.M{"_id": id})
+
+	data := model.Crypto{}
+
+	if err := result.Decode(&data); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return model.Crypto{}, ErrNotFound
+		}
+		return model.Crypto{}, err
+	}
+	return data, nil
+}
+
+func (r *mongoRepository) GetBySymbol(ctx context.Context, symbol string) (model.Crypto, error) {
+	if symbol == "" {
+		return model.Crypto{}, ErrNotFound
+	}
+
+	result := r.collection.FindOne(ctx, bson.M{"symbol": symbol}, options.FindOne().SetCollation(mongoCaseInsensitive))
+
+	data := model.Crypto{}
+
+	if err := result.Decode(&data); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return model.Crypto{}, ErrNotFound
+		}
+		return model.Crypto{}, err
+	}
+	return data, nil
+}
+
+// mongoSortKeys - Document field of each SortField, net votes are computed by the pipeline
+var mongoSortKeys = map[SortField]string{
+	SortByCreated:  "_id",
+	SortByName:     "name",
+	SortByUpvote:   "upvote",
+	SortByDownvote: "downvote",
+	SortByNetVotes: "net_votes",
+
+	SortByWilson:        "wilson_score",
+	SortByHot:           "hot_score",
+	SortByControversial: "controversial_score",
+}
+
+// List runs query as an aggregation so net votes can be sorted and paged on the server
+func (r *mongoRepository) List(ctx context.Context, query ListQuery) ([]model.Crypto, error) {
+	filter := bson.M{}
+	if query.NameContains != "" {
+		filter["name"] = primitive.Regex{Pattern: regexp.QuoteMeta(query.NameContains), Options: "i"}
+	}
+	if query.DescriptionContains != "" {
+		filter["description"] = primitive.Regex{Pattern: regexp.QuoteMeta(query.DescriptionContains), Options: "i"}
+	}
+
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: filter}}}
+	if query.OrderBy == SortByNetVotes {
+		pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.M{
+			"net_votes": bson.M{"$subtract": bson.A{"$upvote", "$downvote"}},
+		}}})
+	}
+
+	key := mongoSortKeys[query.OrderBy]
+	direction, after := 1, "$gt"
+	if query.Descending {
+		direction, after = -1, "$lt"
+	}
+
+	if query.After != nil {
+		var cursor bson.M
+		switch query.OrderBy {
+		case SortByCreated:
+			cursor = bson.M{"_id": bson.M{after: query.After.ID}}
+		default:
+			value := sortValue(query.OrderBy, *query.After)
+			cursor = bson.M{"$or": bson.A{
+				bson.M{key: bson.M{after: value}},
+				bson.M{key: value, "_id": bson.M{after: query.After.ID}},
+			}}
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: cursor}})
+	}
+
+	sortKeys := bson.D{{Key: "_id", Value: direction}}
+	if query.OrderBy != SortByCreated {
+		sortKeys = append(bson.D{{Key: key, Value: direction}}, sortKeys...)
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: sortKeys}})
+
+	if query.Limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: query.Limit}})
+	}
+
+	pointer, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	defer pointer.Close(ctx)
+
+	var result []model.Crypto
+	for pointer.Next(ctx) {
+		data := model.Crypto{}
+		if err := pointer.Decode(&data); err != nil {
+			return nil, err
+		}
+		result = append(result, data)
+	}
+	if err := pointer.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// mongoSearchScanLimit - Most cryptocurrencies read by the prefix and typo scan of a search
+const mongoSearchScanLimit = 1000
+
+// Search ranks the matches of the text index, which only finds whole stemmed words, together with
+// the ones of a scan for words starting with the query terms or one typo away from them
+func (r *mongoRepository) Search(ctx context.Context, query string, limit int) ([]SearchResult, error) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	textScore := bson.M{"$meta": "textScore"}
+	candidates, err := r.find(ctx, bson.M{"$text": bson.M{"$search": strings.Join(terms, " ")}},
+		options.Find().SetProjection(bson.M{"score": textScore}).SetSort(bson.M{"score": textScore}).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(candidates) < limit {
+		pattern := primitive.Regex{Pattern: searchPattern(terms), Options: "i"}
+		scanned, err := r.find(ctx, bson.M{"$or": bson.A{bson.M{"name": pattern}, bson.M{"description": pattern}}},
+			options.Find().SetLimit(mongoSearchScanLimit))
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, scanned...)
+	}
+
+	seen := make(map[primitive.ObjectID]struct{})
+	var results []SearchResult
+	for _, crypto := range candidates {
+		if _, found := seen[crypto.ID]; found {
+			continue
+		}
+		seen[crypto.ID] = struct{}{}
+
+		// Stemmed matches the shared scoring doesn't recognise still rank after the others
+		relevance := cryptoRelevance(terms, crypto)
+		if relevance == 0 {
+			relevance = fuzzyMatch / 2
+		}
+		results = append(results, SearchResult{Crypto: crypto, Relevance: relevance})
+	}
+	return rankSearchResults(results, limit), nil
+}
+
+// searchPattern - Regular expression of the words starting with a term or one typo away from it
+func searchPattern(terms []string) string {
+	var alternatives []string
+	for _, term := range terms {
+		// The term itself also matches the words it starts, so insertions at the end are covered
+		alternatives = append(alternatives, regexp.QuoteMeta(term))
+
+		runes := []rune(term)
+		if len(runes) < fuzzyMinLength {
+			continue
+		}
+		// Words with the rune at i deleted, replaced or preceded by another one
+		for i := range runes {
+			before := regexp.QuoteMeta(string(runes[:i]))
+			deleted := before + regexp.QuoteMeta(string(runes[i+1:]))
+			replaced := before + "." + regexp.QuoteMeta(string(runes[i+1:]))
+			inserted := before + "." + regexp.QuoteMeta(string(runes[i:]))
+			alternatives = append(alternatives, deleted, replaced, inserted)
+		}
+	}
+	return `(^|[^\p{L}\p{N}])(` + strings.Join(alternatives, "|") + `)`
+}
+
+func (r *mongoRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]model.Crypto, error) {
+	pointer, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []model.Crypto
+	if err := pointer.All(ctx, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *mongoRepository) Update(ctx context.Context, crypto model.Crypto) (model.Crypto, error) {
+	set := bson.M{
+		"name":        crypto.Name,
+		"description": crypto.Description,
+	}
+	unset := bson.M{}
+
+	// Empty fields are removed like Create omits them, so the partial symbol index skips them
+	optional := func(field string, value interface{}, empty bool) {
+		if empty {
+			unset[field] = ""
+		} else {
+			set[field] = value
+		}
+	}
+	optional("symbol", crypto.Symbol, crypto.Symbol == "")
+	optional("website", crypto.Website, crypto.Website == "")
+	optional("logo_url", crypto.LogoURL, crypto.LogoURL == "")
+	optional("launch_date", crypto.LaunchDate, crypto.LaunchDate.IsZero())
+	optional("tags", crypto.Tags, len(crypto.Tags) == 0)
+
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	updated, err := r.findOneAndUpdate(ctx, crypto.ID, update)
+	if isDuplicateKeyError(err) {
+		return model.Crypto{}, ErrAlreadyExists
+	}
+	return updated, err
+}
+
+// Delete removes the cryptocurrency and its votes in one transaction
+func (r *mongoRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.withTransaction(ctx, func(sessionCtx mongo.SessionContext) (interface{}, error) {
+		result, err := r.collection.DeleteOne(sessionCtx, bson.M{"_id": id})
+		if err != nil {
+			return nil, err
+		}
+
+		if result.DeletedCount == 0 {
+			return nil, ErrNotFound
+		}
+
+		_, err = r.votes.DeleteMany(sessionCtx, bson.M{"crypto_id": id})
+		return nil, err
+	})
+	return err
+}
+
+// UpdateVote swaps the vote document conditionally on the direction read and adjusts the counters
+// with a single $inc and then the stored scores, all in one transaction so they can't disagree. It
+// retries when a concurrent request changed the vote first
+func (r *mongoRepository) UpdateVote(ctx context.Context, id primitive.ObjectID, voterID string, decide VoteDecision) (model.Crypto, error) {
+	for {
+		result, err := r.withTransaction(ctx, func(sessionCtx mongo.SessionContext) (interface{}, error) {
+			crypto, err := r.GetByID(sessionCtx, id)
+			if err != nil {
+				return nil, err
+			}
+
+			filter := bson.M{"crypto_id": id, "voter_id": voterID}
+
+			previous := model.Vote{}
+			if err := r.votes.FindOne(sessionCtx, filter).Decode(&previous); err != nil && err != mongo.ErrNoDocuments {
+				return nil, err
+			}
+
+			next, err := decide(previous.Direction)
+			if err != nil {
+				return nil, err
+			}
+			if next == previous.Direction {
+				return crypto, nil
+			}
+
+			now := time.Now()
+			swapped, err := r.swapVote(sessionCtx, filter, previous.Direction, model.Vote{CryptoID: id, VoterID: voterID, Direction: next, UpdatedAt: now})
+			if err != nil {
+				return nil, err
+			}
+			if !swapped {
+				return nil, errVoteChanged
+			}
+
+			upvote, downvote := voteDelta(previous.Direction, next)
+			crypto, err = r.findOneAndUpdate(sessionCtx, id, bson.M{
+				"$inc": bson.M{"upvote": upvote, "downvote": downvote},
+				"$set": bson.M{"last_vote_at": now},
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			// Scored from the stored document so they match the ones List recomputes for cursors
+			if _, err := r.collection.UpdateOne(sessionCtx, bson.M{"_id": id}, bson.M{"$set": mongoScores(crypto)}); err != nil {
+				return nil, err
+			}
+			return crypto, nil
+		})
+		if err == errVoteChanged {
+			continue
+		}
+		if err != nil {
+			return model.Crypto{}, err
+		}
+		return result.(model.Crypto), nil
+	}
+}
+
+// errVoteChanged - Aborts the vote transaction when a concurrent request changed the vote first
+var errVoteChanged = errors.New("vote changed concurrently")
+
+// withTransaction - Runs fn in a transaction of a new session, retried by the driver on transient
+// errors. Transactions require MongoDB to run as a replica set
+func (r *mongoRepository) withTransaction(ctx context.Context, fn func(sessionCtx mongo.SessionContext) (interface{}, error)) (interface{}, error) {
+	session, err := r.collection.Database().Client().StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	return session.WithTransaction(ctx, fn)
+}
+
+// swapVote - Replaces the vote matched by filter only if it still has the previous direction
+func (r *mongoRepository) swapVote(ctx context.Context, filter bson.M, previous model.VoteDirection, next model.Vote) (bool, error) {
+	if previous == model.NoVote {
+		_, err := r.votes.InsertOne(ctx, next)
+		if isDuplicateKeyError(err) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	filter["direction"] = previous
+
+	if next.Direction == model.NoVote {
+		result, err := r.votes.DeleteOne(ctx, filter)
+		if err != nil {
+			return false, err
+		}
+		return result.DeletedCount == 1, nil
+	}
+
+	result, err := r.votes.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"direction": next.Direction, "updated_at": next.UpdatedAt}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount == 1, nil
+}
+
+func (r *mongoRepository) SaveSnapshots(ctx context.Context, snapshots []model.Snapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, len(snapshots))
+	for i, snapshot := range snapshots {
+		documents[i] = snapshot
+	}
+
+	// Unordered inserts keep going past snapshots another replica already recorded
+	_, err := r.snapshots.InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+	if err != nil && !isOnlyDuplicateKeyErrors(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *mongoRepository) LastSnapshotTime(ctx context.Context) (time.Time, error) {
+	result := r.snapshots.FindOne(ctx, bson.M{}, options.FindOne().SetSort(bson.M{"timestamp": -1}))
+
+	snapshot := model.Snapshot{}
+	if err := result.Decode(&snapshot); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+	return snapshot.Timestamp, nil
+}
+
+func (r *mongoRepository) ListSnapshots(ctx context.Context, id primitive.ObjectID, from time.Time, to time.Time) ([]model.Snapshot, error) {
+	filter := bson.M{
+		"crypto_id": id,
+		"timestamp": bson.M{"$gte": from, "$lte": to},
+	}
+
+	pointer, err := r.snapshots.Find(ctx, filter, options.Find().SetSort(bson.M{"timestamp": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	var result []model.Snapshot
+	if err := pointer.All(ctx, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *mongoRepository) findOneAndUpdate(ctx context.Context, id primitive.ObjectID, update bson.M) (model.Crypto, error) {
+	result := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, update, options.FindOneAndUpdate().SetReturnDocument(options.After))
+
+	newCrypto := model.Crypto{}
+
+	if err := result.Decode(&newCrypto); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return model.Crypto{}, ErrNotFound
+		}
+		return model.Crypto{}, err
+	}
+	return newCrypto, nil
+}
+
+// mongoDuplicateKey - MongoDB server error code of unique index violations
+const mongoDuplicateKey = 11000
+
+// isOnlyDuplicateKeyErrors - Reports whether every failed write of a bulk insert hit a unique index
+func isOnlyDuplicateKeyErrors(err error) bool {
+	bulkException, ok := err.(mongo.BulkWriteException)
+	if !ok || bulkException.WriteConcernError != nil {
+		return false
+	}
+	for _, writeError := range bulkException.WriteErrors {
+		if writeError.Code != mongoDuplicateKey {
+			return false
+		}
+	}
+	return true
+}
+
+// UseNonce takes over expired nonces the TTL index hasn't deleted yet, the upsert of a nonce still in use
+// collides with it on _id
+func (r *mongoRepository) UseNonce(ctx context.Context, key string, nonce string, expires time.Time) error {
+	filter := bson.M{"_id": key + "|" + nonce, "expires_at": bson.M{"$lt": time.Now()}}
+	update := bson.M{"$set": bson.M{"expires_at": expires}}
+
+	_, err := r.nonces.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if isDuplicateKeyError(err) {
+		return ErrNonceUsed
+	}
+	return err
+}
+
+func isDuplicateKeyError(err error) bool {
+	switch err := err.(type) {
+	case mongo.WriteException:
+		for _, writeError := range err.WriteErrors {
+			if writeError.Code == mongoDuplicateKey {
+				return true
+			}
+		}
+	case mongo.CommandError:
+		// findAndModify reports index violations as a command error
+		return err.Code == mongoDuplicateKey
+	}
+	return false
+}
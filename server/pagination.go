@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	upvoteSystem "github.com/RomuloSiebra/CryptoUpvoteSystem/proto/UpvoteSystem"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxPageSize - Largest ReadAllCrypto page, bigger page sizes are capped
+const maxPageSize = 1000
+
+// errInvalidPageToken - Rejects a page token that can't be decoded or was issued for another order
+var errInvalidPageToken = errors.New("invalid page token")
+
+// sortFields - SortField of each proto order
+var sortFields = map[upvoteSystem.CryptoOrder]SortField{
+	upvoteSystem.CryptoOrder_ORDER_CREATED:       SortByCreated,
+	upvoteSystem.CryptoOrder_ORDER_NAME:          SortByName,
+	upvoteSystem.CryptoOrder_ORDER_UPVOTE:        SortByUpvote,
+	upvoteSystem.CryptoOrder_ORDER_DOWNVOTE:      SortByDownvote,
+	upvoteSystem.CryptoOrder_ORDER_NET_VOTES:     SortByNetVotes,
+	upvoteSystem.CryptoOrder_ORDER_WILSON:        SortByWilson,
+	upvoteSystem.CryptoOrder_ORDER_HOT:           SortByHot,
+	upvoteSystem.CryptoOrder_ORDER_CONTROVERSIAL: SortByControversial,
+}
+
+// pageToken - Order and sort key of the last cryptocurrency of a page, so the next page
+// continues after it even when cryptocurrencies are added or removed in between
+type pageToken struct {
+	OrderBy    SortField `json:"o"`
+	Descending bool      `json:"d,omitempty"`
+	ID         string    `json:"i"`
+	Name       string    `json:"n,omitempty"`
+	Upvote     int32     `json:"u,omitempty"`
+	Downvote   int32     `json:"v,omitempty"`
+	LastVoteAt int64     `json:"t,omitempty"` // Unix nanoseconds, computed scores may depend on it
+}
+
+// encodePageToken - Returns the token of the page ending with last
+func encodePageToken(query ListQuery, last model.Crypto) string {
+	token := pageToken{OrderBy: query.OrderBy, Descending: query.Descending, ID: last.ID.Hex()}
+	switch query.OrderBy {
+	case SortByName:
+		token.Name = last.Name
+	case SortByUpvote:
+		token.Upvote = last.Upvote
+	case SortByDownvote:
+		token.Downvote = last.Downvote
+	case SortByNetVotes:
+		token.Upvote = last.Upvote
+		token.Downvote = last.Downvote
+	case SortByWilson, SortByHot, SortByControversial:
+		token.Upvote = last.Upvote
+		token.Downvote = last.Downvote
+		if !last.LastVoteAt.IsZero() {
+			token.LastVoteAt = last.LastVoteAt.UnixNano()
+		}
+	}
+
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePageToken - Returns the cursor of a token issued for the order of query
+func decodePageToken(query ListQuery, encoded string) (*model.Crypto, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errInvalidPageToken
+	}
+
+	token := pageToken{}
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, errInvalidPageToken
+	}
+	if token.OrderBy != query.OrderBy || token.Descending != query.Descending {
+		return nil, errInvalidPageToken
+	}
+
+	id, err := primitive.ObjectIDFromHex(token.ID)
+	if err != nil {
+		return nil, errInvalidPageToken
+	}
+	after := &model.Crypto{ID: id, Name: token.Name, Upvote: token.Upvote, Downvote: token.Downvote}
+	if token.LastVoteAt != 0 {
+		after.LastVoteAt = time.Unix(0, token.LastVoteAt)
+	}
+	return after, nil
+}
+
+// listQuery - Builds the ListQuery of a ReadAllCrypto request, the limit fetches one extra
+// cryptocurrency to tell whether another page follows
+func listQuery(request *upvoteSystem.ReadAllCryptoRequest) (ListQuery, error) {
+	orderBy, found := sortFields[request.GetOrderBy()]
+	if !found {
+		return ListQuery{}, status.Errorf(codes.InvalidArgument, "Invalid order")
+	}
+	if request.GetPageSize() < 0 {
+		return ListQuery{}, status.Errorf(codes.InvalidArgument, "Invalid page size")
+	}
+
+	query := ListQuery{
+		NameContains:        request.GetNameFilter(),
+		DescriptionContains: request.GetDescriptionFilter(),
+		OrderBy:             orderBy,
+		Descending:          request.GetDescending(),
+	}
+
+	if request.GetPageToken() != "" {
+		after, err := decodePageToken(query, request.GetPageToken())
+		if err != nil {
+			return ListQuery{}, status.Errorf(codes.InvalidArgument, "Invalid page token")
+		}
+		query.After = after
+	}
+
+	if pageSize := pageSize(request); pageSize > 0 {
+		query.Limit = pageSize + 1
+	}
+	return query, nil
+}
+
+// pageSize - Requested page size capped at maxPageSize, zero when paging is off
+func pageSize(request *upvoteSystem.ReadAllCryptoRequest) int {
+	if request.GetPageSize() > maxPageSize {
+		return maxPageSize
+	}
+	return int(request.GetPageSize())
+}
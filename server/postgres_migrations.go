@@ -0,0 +1,268 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// postgresMigration - Versioned schema change applied once by migratePostgres
+type postgresMigration struct {
+	version     int
+	description string
+	// prepare - Optional data fix run in the migration transaction before its statements
+	prepare    func(ctx context.Context, tx *sql.Tx) error
+	statements []string
+	// backfill - Optional data fix run in the migration transaction after its statements
+	backfill func(ctx context.Context, tx *sql.Tx) error
+}
+
+// postgresMigrations - Schema history, append new versions and never edit applied ones
+var postgresMigrations = []postgresMigration{
+	{
+		version:     1,
+		description: "create cryptocurrency table",
+		statements: []string{
+			`CREATE TABLE cryptocurrency (
+				id          CHAR(24) PRIMARY KEY,
+				name        TEXT NOT NULL,
+				description TEXT NOT NULL,
+				upvote      INTEGER NOT NULL DEFAULT 0,
+				downvote    INTEGER NOT NULL DEFAULT 0,
+				CONSTRAINT cryptocurrency_name_key UNIQUE (name)
+			)`,
+		},
+	},
+	{
+		version:     2,
+		description: "create vote table",
+		statements: []string{
+			`CREATE TABLE vote (
+				crypto_id  CHAR(24) NOT NULL REFERENCES cryptocurrency (id) ON DELETE CASCADE,
+				voter_id   TEXT NOT NULL,
+				direction  SMALLINT NOT NULL CHECK (direction IN (-1, 1)),
+				updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+				PRIMARY KEY (crypto_id, voter_id)
+			)`,
+		},
+	},
+	{
+		version:     3,
+		description: "create snapshot table",
+		statements: []string{
+			`CREATE TABLE snapshot (
+				crypto_id CHAR(24) NOT NULL,
+				upvote    INTEGER NOT NULL,
+				downvote  INTEGER NOT NULL,
+				sum       INTEGER NOT NULL,
+				taken_at  TIMESTAMPTZ NOT NULL,
+				PRIMARY KEY (crypto_id, taken_at)
+			)`,
+			`CREATE INDEX snapshot_taken_at_idx ON snapshot (taken_at)`,
+		},
+	},
+	{
+		version:     4,
+		description: "index cryptocurrency sort keys",
+		statements: []string{
+			`CREATE INDEX cryptocurrency_name_id_idx ON cryptocurrency ((name COLLATE "C"), id)`,
+			`CREATE INDEX cryptocurrency_upvote_id_idx ON cryptocurrency (upvote, id)`,
+			`CREATE INDEX cryptocurrency_downvote_id_idx ON cryptocurrency (downvote, id)`,
+			`CREATE INDEX cryptocurrency_net_votes_id_idx ON cryptocurrency ((upvote - downvote), id)`,
+		},
+	},
+	{
+		version:     5,
+		description: "add cryptocurrency last vote time",
+		statements: []string{
+			`ALTER TABLE cryptocurrency ADD COLUMN last_vote_at TIMESTAMPTZ`,
+		},
+	},
+	{
+		version:     6,
+		description: "add cryptocurrency metadata and case-insensitive uniqueness",
+		prepare:     renamePostgresCaseConflicts,
+		statements: []string{
+			`ALTER TABLE cryptocurrency
+				ADD COLUMN symbol      TEXT,
+				ADD COLUMN website     TEXT NOT NULL DEFAULT '',
+				ADD COLUMN logo_url    TEXT NOT NULL DEFAULT '',
+				ADD COLUMN launch_date TIMESTAMPTZ,
+				ADD COLUMN tags        TEXT[] NOT NULL DEFAULT '{}'`,
+			`ALTER TABLE cryptocurrency DROP CONSTRAINT cryptocurrency_name_key`,
+			`CREATE UNIQUE INDEX cryptocurrency_name_key ON cryptocurrency (lower(name))`,
+			`CREATE UNIQUE INDEX cryptocurrency_symbol_key ON cryptocurrency (lower(symbol))`,
+		},
+	},
+	{
+		version:     7,
+		description: "create rate limit table",
+		statements: []string{
+			`CREATE TABLE rate_limit (
+				key        TEXT PRIMARY KEY,
+				tokens     DOUBLE PRECISION NOT NULL,
+				allowed    BOOLEAN NOT NULL,
+				updated_at TIMESTAMPTZ NOT NULL,
+				expires_at TIMESTAMPTZ NOT NULL
+			)`,
+			`CREATE INDEX rate_limit_expires_at_idx ON rate_limit (expires_at)`,
+		},
+	},
+	{
+		version:     8,
+		description: "create vote nonce table",
+		statements: []string{
+			`CREATE TABLE vote_nonce (
+				key        TEXT NOT NULL,
+				nonce      TEXT NOT NULL,
+				expires_at TIMESTAMPTZ NOT NULL,
+				PRIMARY KEY (key, nonce)
+			)`,
+			`CREATE INDEX vote_nonce_expires_at_idx ON vote_nonce (expires_at)`,
+		},
+	},
+	{
+		version:     9,
+		description: "store and index cryptocurrency ranking scores",
+		statements: []string{
+			`ALTER TABLE cryptocurrency
+				ADD COLUMN wilson_score        DOUBLE PRECISION NOT NULL DEFAULT 0,
+				ADD COLUMN hot_score           DOUBLE PRECISION NOT NULL DEFAULT 0,
+				ADD COLUMN controversial_score DOUBLE PRECISION NOT NULL DEFAULT 0`,
+			`CREATE INDEX cryptocurrency_wilson_score_id_idx ON cryptocurrency (wilson_score, id)`,
+			`CREATE INDEX cryptocurrency_hot_score_id_idx ON cryptocurrency (hot_score, id)`,
+			`CREATE INDEX cryptocurrency_controversial_score_id_idx ON cryptocurrency (controversial_score, id)`,
+		},
+		backfill: scorePostgresCryptos,
+	},
+}
+
+// postgresMigrationLock - Advisory lock key serialising migrations between server replicas
+const postgresMigrationLock = 4627361
+
+// migratePostgres - Applies every migration newer than the recorded schema version
+func migratePostgres(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version     INTEGER PRIMARY KEY,
+		description TEXT NOT NULL,
+		applied_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range postgresMigrations {
+		if err := applyPostgresMigration(ctx, db, migration); err != nil {
+			return fmt.Errorf("migration %d (%s): %v", migration.version, migration.description, err)
+		}
+	}
+	return nil
+}
+
+func applyPostgresMigration(ctx context.Context, db *sql.DB, migration postgresMigration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", postgresMigrationLock); err != nil {
+		return err
+	}
+
+	var applied bool
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)", migration.version).Scan(&applied)
+	if err != nil {
+		return err
+	}
+	if applied {
+		return nil
+	}
+
+	if migration.prepare != nil {
+		if err := migration.prepare(ctx, tx); err != nil {
+			return err
+		}
+	}
+	for _, statement := range migration.statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	if migration.backfill != nil {
+		if err := migration.backfill(ctx, tx); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, description) VALUES ($1, $2)", migration.version, migration.description)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// renamePostgresCaseConflicts - Renames the cryptocurrencies whose names only differ in case, created
+// before migration 6, so the unique index on lower(name) can be built
+func renamePostgresCaseConflicts(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, "SELECT id, name FROM cryptocurrency ORDER BY id")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var cryptos []model.Crypto
+	for rows.Next() {
+		var id string
+		crypto := model.Crypto{}
+		if err := rows.Scan(&id, &crypto.Name); err != nil {
+			return err
+		}
+		if crypto.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+			return err
+		}
+		cryptos = append(cryptos, crypto)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, crypto := range resolveCaseConflicts(cryptos) {
+		if _, err := tx.ExecContext(ctx, "UPDATE cryptocurrency SET name = $1 WHERE id = $2", crypto.Name, crypto.ID.Hex()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scorePostgresCryptos - Stores the scores of the cryptocurrencies created before migration 9, the
+// scores are computed in Go like the ones written by votes
+func scorePostgresCryptos(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, "SELECT "+cryptoColumns+" FROM cryptocurrency")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var cryptos []model.Crypto
+	for rows.Next() {
+		crypto, err := scanCrypto(rows)
+		if err != nil {
+			return err
+		}
+		cryptos = append(cryptos, crypto)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, crypto := range cryptos {
+		if err := storePostgresScores(ctx, tx, crypto); err != nil {
+			return err
+		}
+	}
+	return nil
+}
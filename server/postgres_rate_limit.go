@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+)
+
+// postgresRefilledTokens - Tokens of an existing rate_limit row refilled up to $3, at most $2
+const postgresRefilledTokens = `LEAST($2::double precision, rate_limit.tokens +
+	$4::double precision * GREATEST(0, EXTRACT(EPOCH FROM $3::timestamptz - rate_limit.updated_at)))`
+
+// postgresTakeToken - Creates the bucket of $1 full and takes a token, or refills and takes one when
+// there is one. SET expressions all read the row before the update
+const postgresTakeToken = `INSERT INTO rate_limit (key, tokens, allowed, updated_at, expires_at)
+	VALUES ($1, $2::double precision - 1, true, $3, $5)
+	ON CONFLICT (key) DO UPDATE SET
+		tokens = CASE WHEN ` + postgresRefilledTokens + ` >= 1 THEN ` + postgresRefilledTokens + ` - 1 ELSE ` + postgresRefilledTokens + ` END,
+		allowed = ` + postgresRefilledTokens + ` >= 1,
+		updated_at = GREATEST(rate_limit.updated_at, $3),
+		expires_at = $5
+	RETURNING tokens, allowed`
+
+// postgresRefundToken - Puts a token back in the bucket of $1, up to $2
+const postgresRefundToken = `UPDATE rate_limit SET tokens = LEAST($2::double precision, tokens + 1) WHERE key = $1`
+
+// postgresRateLimitStore - Buckets in the rate_limit table, replicas on the same database share them
+type postgresRateLimitStore struct {
+	db      *sql.DB
+	sweeper sweeper
+}
+
+func newPostgresRateLimitStore(repository *postgresRepository) *postgresRateLimitStore {
+	return &postgresRateLimitStore{db: repository.db}
+}
+
+func (s *postgresRateLimitStore) Take(ctx context.Context, key string, limit rateLimit, now time.Time) (bool, time.Duration, error) {
+	if s.sweeper.due(now) {
+		if _, err := s.db.ExecContext(ctx, "DELETE FROM rate_limit WHERE expires_at < $1", now); err != nil {
+			log.Printf("Error: deleting idle rate limits: %v", err)
+		}
+	}
+
+	var tokens float64
+	var allowed bool
+	err := s.db.QueryRowContext(ctx, postgresTakeToken, key, float64(limit.count), now, limit.rate(), now.Add(limit.period)).Scan(&tokens, &allowed)
+	if err != nil {
+		return false, 0, err
+	}
+
+	if allowed {
+		return true, 0, nil
+	}
+	return false, time.Duration((1 - tokens) / limit.rate() * float64(time.Second)), nil
+}
+
+func (s *postgresRateLimitStore) Refund(ctx context.Context, key string, limit rateLimit) error {
+	_, err := s.db.ExecContext(ctx, postgresRefundToken, key, float64(limit.count))
+	return err
+}
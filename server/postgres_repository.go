@@ -0,0 +1,411 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	"github.com/lib/pq"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// postgresUniqueViolation - SQLSTATE raised when a unique constraint rejects a row
+const postgresUniqueViolation = "23505"
+
+const cryptoColumns = "id, name, description, symbol, website, logo_url, launch_date, tags, upvote, downvote, last_vote_at"
+
+// scoreColumns - Stored Ranking scores, in the order of storedScores
+const scoreColumns = "wilson_score, hot_score, controversial_score"
+
+// postgresRepository - CryptoRepository stored in PostgreSQL
+type postgresRepository struct {
+	db           *sql.DB
+	schema       string
+	nonceSweeper sweeper
+}
+
+// openPostgresRepository - Connects to the PostgreSQL at connection URL and migrates schema
+func openPostgresRepository(ctx context.Context, connection string, schema string) (*postgresRepository, error) {
+	connectionURL, err := url.Parse(connection)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every connection of the pool resolves tables inside schema
+	query := connectionURL.Query()
+	query.Set("search_path", schema)
+	connectionURL.RawQuery = query.Encode()
+
+	db, err := sql.Open("postgres", connectionURL.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := db.ExecContext(ctx, "CREATE SCHEMA IF NOT EXISTS "+pq.QuoteIdentifier(schema)); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	if err := migratePostgres(ctx, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &postgresRepository{db: db, schema: schema}, nil
+}
+
+// Close closes every pooled connection
+func (r *postgresRepository) Close() error {
+	return r.db.Close()
+}
+
+func (r *postgresRepository) Create(ctx context.Context, crypto model.Crypto) (model.Crypto, error) {
+	if crypto.ID.IsZero() {
+		crypto.ID = primitive.NewObjectID()
+	}
+
+	// Scored at the precision of the column, so the scores match the ones List recomputes for cursors
+	crypto.LastVoteAt = crypto.LastVoteAt.Truncate(time.Microsecond)
+	args := []interface{}{
+		crypto.ID.Hex(), crypto.Name, crypto.Description, nullString(crypto.Symbol), crypto.Website, crypto.LogoURL,
+		nullTime(crypto.LaunchDate), tagsArray(crypto.Tags), crypto.Upvote, crypto.Downvote, nullTime(crypto.LastVoteAt),
+	}
+	args = append(args, postgresScores(crypto)...)
+
+	row := r.db.QueryRowContext(ctx,
+		"INSERT INTO cryptocurrency ("+cryptoColumns+", "+scoreColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING "+cryptoColumns,
+		args...)
+	return scanCrypto(row)
+}
+
+func (r *postgresRepository) GetByID(ctx context.Context, id primitive.ObjectID) (model.Crypto, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+cryptoColumns+" FROM cryptocurrency WHERE id = $1", id.Hex())
+	return scanCrypto(row)
+}
+
+func (r *postgresRepository) GetBySymbol(ctx context.Context, symbol string) (model.Crypto, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+cryptoColumns+" FROM cryptocurrency WHERE lower(symbol) = lower($1)", symbol)
+	return scanCrypto(row)
+}
+
+// postgresSortKeys - Expression of each SortField, names compare bytewise like the other backends
+var postgresSortKeys = map[SortField]string{
+	SortByCreated:  "id",
+	SortByName:     `(name COLLATE "C")`,
+	SortByUpvote:   "upvote",
+	SortByDownvote: "downvote",
+	SortByNetVotes: "(upvote - downvote)",
+
+	SortByWilson:        "wilson_score",
+	SortByHot:           "hot_score",
+	SortByControversial: "controversial_score",
+}
+
+func (r *postgresRepository) List(ctx context.Context, query ListQuery) ([]model.Crypto, error) {
+	var conditions []string
+	var args []interface{}
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if query.NameContains != "" {
+		conditions = append(conditions, "strpos(lower(name), lower("+arg(query.NameContains)+")) > 0")
+	}
+	if query.DescriptionContains != "" {
+		conditions = append(conditions, "strpos(lower(description), lower("+arg(query.DescriptionContains)+")) > 0")
+	}
+
+	key := postgresSortKeys[query.OrderBy]
+	direction, after := "ASC", ">"
+	if query.Descending {
+		direction, after = "DESC", "<"
+	}
+
+	if query.After != nil {
+		switch query.OrderBy {
+		case SortByCreated:
+			conditions = append(conditions, "id "+after+" "+arg(query.After.ID.Hex()))
+		default:
+			value := arg(sortValue(query.OrderBy, *query.After))
+			conditions = append(conditions, "("+key+", id) "+after+" ("+value+", "+arg(query.After.ID.Hex())+")")
+		}
+	}
+
+	statement := "SELECT " + cryptoColumns + " FROM cryptocurrency"
+	if len(conditions) > 0 {
+		statement += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	if query.OrderBy == SortByCreated {
+		statement += " ORDER BY id " + direction
+	} else {
+		statement += " ORDER BY " + key + " " + direction + ", id " + direction
+	}
+	if query.Limit > 0 {
+		statement += " LIMIT " + arg(query.Limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []model.Crypto
+	for rows.Next() {
+		crypto, err := scanCrypto(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, crypto)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *postgresRepository) Update(ctx context.Context, crypto model.Crypto) (model.Crypto, error) {
+	row := r.db.QueryRowContext(ctx,
+		`UPDATE cryptocurrency SET name = $2, description = $3, symbol = $4, website = $5, logo_url = $6, launch_date = $7, tags = $8
+		WHERE id = $1 RETURNING `+cryptoColumns,
+		crypto.ID.Hex(), crypto.Name, crypto.Description, nullString(crypto.Symbol), crypto.Website, crypto.LogoURL,
+		nullTime(crypto.LaunchDate), tagsArray(crypto.Tags))
+	return scanCrypto(row)
+}
+
+func (r *postgresRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM cryptocurrency WHERE id = $1", id.Hex())
+	if err != nil {
+		return err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *postgresRepository) UpdateVote(ctx context.Context, id primitive.ObjectID, voterID string, decide VoteDecision) (model.Crypto, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return model.Crypto{}, err
+	}
+	defer tx.Rollback()
+
+	// Locking the cryptocurrency row serialises concurrent votes of the same voter
+	crypto, err := scanCrypto(tx.QueryRowContext(ctx, "SELECT "+cryptoColumns+" FROM cryptocurrency WHERE id = $1 FOR UPDATE", id.Hex()))
+	if err != nil {
+		return model.Crypto{}, err
+	}
+
+	var previous model.VoteDirection
+	err = tx.QueryRowContext(ctx, "SELECT direction FROM vote WHERE crypto_id = $1 AND voter_id = $2", id.Hex(), voterID).Scan(&previous)
+	if err != nil && err != sql.ErrNoRows {
+		return model.Crypto{}, err
+	}
+
+	next, err := decide(previous)
+	if err != nil {
+		return model.Crypto{}, err
+	}
+	if next == previous {
+		return crypto, nil
+	}
+
+	if next == model.NoVote {
+		_, err = tx.ExecContext(ctx, "DELETE FROM vote WHERE crypto_id = $1 AND voter_id = $2", id.Hex(), voterID)
+	} else {
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO vote (crypto_id, voter_id, direction, updated_at) VALUES ($1, $2, $3, now())
+			ON CONFLICT (crypto_id, voter_id) DO UPDATE SET direction = EXCLUDED.direction, updated_at = EXCLUDED.updated_at`,
+			id.Hex(), voterID, next)
+	}
+	if err != nil {
+		return model.Crypto{}, err
+	}
+
+	upvote, downvote := voteDelta(previous, next)
+	crypto, err = scanCrypto(tx.QueryRowContext(ctx,
+		"UPDATE cryptocurrency SET upvote = upvote + $2, downvote = downvote + $3, last_vote_at = now() WHERE id = $1 RETURNING "+cryptoColumns,
+		id.Hex(), upvote, downvote))
+	if err != nil {
+		return model.Crypto{}, err
+	}
+
+	// Scored from the stored row so they match the ones List recomputes for cursors
+	if err := storePostgresScores(ctx, tx, crypto); err != nil {
+		return model.Crypto{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return model.Crypto{}, err
+	}
+	return crypto, nil
+}
+
+// postgresScores - Values of scoreColumns for crypto
+func postgresScores(crypto model.Crypto) []interface{} {
+	var scores []interface{}
+	for _, score := range storedScores {
+		scores = append(scores, rankings[score.field].Score(crypto))
+	}
+	return scores
+}
+
+// storePostgresScores - Writes the scores of crypto in its row
+func storePostgresScores(ctx context.Context, tx *sql.Tx, crypto model.Crypto) error {
+	args := append([]interface{}{crypto.ID.Hex()}, postgresScores(crypto)...)
+	_, err := tx.ExecContext(ctx,
+		"UPDATE cryptocurrency SET wilson_score = $2, hot_score = $3, controversial_score = $4 WHERE id = $1", args...)
+	return err
+}
+
+func (r *postgresRepository) SaveSnapshots(ctx context.Context, snapshots []model.Snapshot) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, snapshot := range snapshots {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO snapshot (crypto_id, upvote, downvote, sum, taken_at) VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (crypto_id, taken_at) DO NOTHING`,
+			snapshot.CryptoID.Hex(), snapshot.Upvote, snapshot.Downvote, snapshot.Sum, snapshot.Timestamp)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *postgresRepository) LastSnapshotTime(ctx context.Context) (time.Time, error) {
+	var last pq.NullTime
+
+	if err := r.db.QueryRowContext(ctx, "SELECT MAX(taken_at) FROM snapshot").Scan(&last); err != nil {
+		return time.Time{}, err
+	}
+	if !last.Valid {
+		return time.Time{}, nil
+	}
+	return last.Time, nil
+}
+
+func (r *postgresRepository) ListSnapshots(ctx context.Context, id primitive.ObjectID, from time.Time, to time.Time) ([]model.Snapshot, error) {
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT upvote, downvote, sum, taken_at FROM snapshot WHERE crypto_id = $1 AND taken_at BETWEEN $2 AND $3 ORDER BY taken_at",
+		id.Hex(), from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []model.Snapshot
+	for rows.Next() {
+		snapshot := model.Snapshot{CryptoID: id}
+		if err := rows.Scan(&snapshot.Upvote, &snapshot.Downvote, &snapshot.Sum, &snapshot.Timestamp); err != nil {
+			return nil, err
+		}
+		result = append(result, snapshot)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// rowScanner - Common interface of *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanCrypto(row rowScanner) (model.Crypto, error) {
+	var id string
+	var symbol sql.NullString
+	var launchDate, lastVoteAt sql.NullTime
+	var tags pq.StringArray
+	crypto := model.Crypto{}
+
+	err := row.Scan(&id, &crypto.Name, &crypto.Description, &symbol, &crypto.Website, &crypto.LogoURL,
+		&launchDate, &tags, &crypto.Upvote, &crypto.Downvote, &lastVoteAt)
+	if err != nil {
+		return model.Crypto{}, postgresError(err)
+	}
+	crypto.Symbol = symbol.String
+	crypto.LaunchDate = launchDate.Time
+	crypto.LastVoteAt = lastVoteAt.Time
+	if len(tags) > 0 {
+		crypto.Tags = tags
+	}
+
+	crypto.ID, err = primitive.ObjectIDFromHex(strings.TrimSpace(id))
+	if err != nil {
+		return model.Crypto{}, err
+	}
+	return crypto, nil
+}
+
+// nullString - NULL for the empty string, so unique indexes ignore missing values
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+// nullTime - NULL for the zero time
+func nullTime(value time.Time) sql.NullTime {
+	return sql.NullTime{Time: value, Valid: !value.IsZero()}
+}
+
+// tagsArray - Tags as a TEXT[] value, empty rather than NULL when there are none
+func tagsArray(tags []string) pq.StringArray {
+	if tags == nil {
+		return pq.StringArray{}
+	}
+	return pq.StringArray(tags)
+}
+
+// postgresError - Translates driver errors into repository errors
+func postgresError(err error) error {
+	if err == sql.ErrNoRows {
+		return ErrNotFound
+	}
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == postgresUniqueViolation {
+		return ErrAlreadyExists
+	}
+	return err
+}
+
+// UseNonce takes over expired nonces not swept yet, the conflicting insert of a nonce still in use
+// updates no row
+func (r *postgresRepository) UseNonce(ctx context.Context, key string, nonce string, expires time.Time) error {
+	now := time.Now()
+	if r.nonceSweeper.due(now) {
+		if _, err := r.db.ExecContext(ctx, "DELETE FROM vote_nonce WHERE expires_at < $1", now); err != nil {
+			log.Printf("Error: deleting expired vote nonces: %v", err)
+		}
+	}
+
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO vote_nonce (key, nonce, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (key, nonce) DO UPDATE SET expires_at = EXCLUDED.expires_at WHERE vote_nonce.expires_at < $4`,
+		key, nonce, expires, now)
+	if err != nil {
+		return err
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if inserted == 0 {
+		return ErrNonceUsed
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const postgresTestSchema = "upvotesystem_postgres_test"
+
+// setupPostgres - Opens a clean schema on POSTGRES_URL, skipping when no PostgreSQL is configured
+func setupPostgres(t *testing.T) *postgresRepository {
+	connection := os.Getenv("POSTGRES_URL")
+	if connection == "" {
+		t.Skip("POSTGRES_URL not set, start one with make run-postgres")
+	}
+
+	repository, err := openPostgresRepository(context.Background(), connection, postgresTestSchema)
+	require.Nil(t, err)
+
+	return repository
+}
+
+func clearPostgres(repository *postgresRepository) {
+	repository.db.Exec("DROP SCHEMA " + pq.QuoteIdentifier(postgresTestSchema) + " CASCADE")
+	repository.Close()
+}
+
+func TestPostgresMigrations(t *testing.T) {
+	repository := setupPostgres(t)
+	defer clearPostgres(repository)
+
+	// Migrating an up to date schema is a no-op
+	require.Nil(t, migratePostgres(context.Background(), repository.db))
+
+	var version int
+	var applied int
+	err := repository.db.QueryRow("SELECT MAX(version), COUNT(*) FROM schema_migrations").Scan(&version, &applied)
+
+	require.Nil(t, err)
+	assert.Equal(t, postgresMigrations[len(postgresMigrations)-1].version, version)
+	assert.Equal(t, len(postgresMigrations), applied)
+}
+
+func TestPostgresUniqueName(t *testing.T) {
+	repository := setupPostgres(t)
+	defer clearPostgres(repository)
+	ctx := context.Background()
+
+	bitcoin := model.Crypto{ID: primitive.NewObjectID(), Name: "Bitcoin", Description: "The most valuable cryptocurrency"}
+
+	_, err := repository.Create(ctx, bitcoin)
+	require.Nil(t, err)
+
+	bitcoin.ID = primitive.NewObjectID()
+	_, err = repository.Create(ctx, bitcoin)
+	assert.Equal(t, ErrAlreadyExists, err)
+
+	_, err = repository.GetByID(ctx, bitcoin.ID)
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestPostgresUpdateVote(t *testing.T) {
+	repository := setupPostgres(t)
+	defer clearPostgres(repository)
+	ctx := context.Background()
+
+	crypto, err := repository.Create(ctx, model.Crypto{ID: primitive.NewObjectID(), Name: "Bitcoin", Description: "The most valuable cryptocurrency"})
+	require.Nil(t, err)
+
+	upvote := func(model.VoteDirection) (model.VoteDirection, error) { return model.Upvote, nil }
+	downvote := func(model.VoteDirection) (model.VoteDirection, error) { return model.Downvote, nil }
+
+	// Concurrent votes of different voters must not lose updates
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(voter int) {
+			defer wg.Done()
+			_, err := repository.UpdateVote(ctx, crypto.ID, fmt.Sprintf("voter-%d", voter), upvote)
+			assert.Nil(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	// Repeating a vote keeps a single vote and switching moves it between counters
+	_, err = repository.UpdateVote(ctx, crypto.ID, "voter-0", upvote)
+	require.Nil(t, err)
+	crypto, err = repository.UpdateVote(ctx, crypto.ID, "voter-1", downvote)
+	require.Nil(t, err)
+
+	assert.Equal(t, int32(19), crypto.Upvote)
+	assert.Equal(t, int32(1), crypto.Downvote)
+
+	_, err = repository.UpdateVote(ctx, primitive.NewObjectID(), "voter-0", upvote)
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestPostgresStoredScores(t *testing.T) {
+	repository := setupPostgres(t)
+	defer clearPostgres(repository)
+	ctx := context.Background()
+
+	upvote := func(model.VoteDirection) (model.VoteDirection, error) { return model.Upvote, nil }
+	downvote := func(model.VoteDirection) (model.VoteDirection, error) { return model.Downvote, nil }
+
+	var ids []primitive.ObjectID
+	for i, votes := range [][]model.VoteDirection{{model.Upvote}, {model.Upvote, model.Downvote}, {model.Downvote}} {
+		crypto, err := repository.Create(ctx, model.Crypto{ID: primitive.NewObjectID(), Name: fmt.Sprintf("Crypto %d", i), Description: "Ranked"})
+		require.Nil(t, err)
+		ids = append(ids, crypto.ID)
+
+		for voter, direction := range votes {
+			decide := upvote
+			if direction == model.Downvote {
+				decide = downvote
+			}
+			_, err := repository.UpdateVote(ctx, crypto.ID, fmt.Sprintf("voter-%d", voter), decide)
+			require.Nil(t, err)
+		}
+	}
+
+	// Test the stored scores page like the ones computed in process
+	for _, score := range storedScores {
+		query := ListQuery{OrderBy: score.field, Descending: true, Limit: 2}
+
+		first, err := repository.List(ctx, query)
+		require.Nil(t, err)
+		require.Len(t, first, 2)
+
+		query.After = &first[1]
+		second, err := repository.List(ctx, query)
+		require.Nil(t, err)
+
+		var all []model.Crypto
+		for _, id := range ids {
+			crypto, err := repository.GetByID(ctx, id)
+			require.Nil(t, err)
+			all = append(all, crypto)
+		}
+		assert.Equal(t, ListQuery{OrderBy: score.field, Descending: true}.apply(all), append(first, second...), score.key)
+	}
+}
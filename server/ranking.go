@@ -0,0 +1,105 @@
+package main
+
+import (
+	"math"
+	"time"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+)
+
+// Ranking - Scoring algorithm of a SortField, higher scores rank first when descending.
+// Scores must only depend on the stored cryptocurrency so pages stay consistent
+type Ranking interface {
+	Score(crypto model.Crypto) float64
+}
+
+// RankingFunc - Ranking computed by a plain function
+type RankingFunc func(crypto model.Crypto) float64
+
+// Score calls f
+func (f RankingFunc) Score(crypto model.Crypto) float64 {
+	return f(crypto)
+}
+
+// rankings - Algorithms of the computed sort fields
+var rankings = map[SortField]Ranking{
+	SortByWilson:        wilsonRanking{z: 1.96},
+	SortByHot:           hotRanking{epoch: time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC), halfLife: 12 * time.Hour},
+	SortByControversial: RankingFunc(controversialScore),
+}
+
+// storedScore - Field or column of a Ranking score in MongoDB and PostgreSQL
+type storedScore struct {
+	field SortField
+	key   string
+}
+
+// storedScores - Scores MongoDB and PostgreSQL store and index next to the counters. They are
+// written with every vote, so listing by a ranking reads one page and not every cryptocurrency
+var storedScores = []storedScore{
+	{field: SortByWilson, key: "wilson_score"},
+	{field: SortByHot, key: "hot_score"},
+	{field: SortByControversial, key: "controversial_score"},
+}
+
+// wilsonRanking - Lower bound of the Wilson score confidence interval of the upvote ratio,
+// few votes get a low bound so a single upvote doesn't beat many mostly positive ones
+type wilsonRanking struct {
+	// z is the normal quantile of the confidence level, 1.96 for 95%
+	z float64
+}
+
+func (r wilsonRanking) Score(crypto model.Crypto) float64 {
+	n := float64(crypto.Upvote) + float64(crypto.Downvote)
+	if n <= 0 {
+		return 0
+	}
+
+	p := float64(crypto.Upvote) / n
+	z2 := r.z * r.z
+	return (p + z2/(2*n) - r.z*math.Sqrt((p*(1-p)+z2/(4*n))/n)) / (1 + z2/n)
+}
+
+// hotRanking - Order of magnitude of the net votes plus a bonus growing with the time of the
+// latest vote, or creation when there is none. An entry needs twice the net votes to stay level
+// with one voted halfLife later, so old popular entries sink without rescoring as time passes
+type hotRanking struct {
+	epoch    time.Time
+	halfLife time.Duration
+}
+
+func (r hotRanking) Score(crypto model.Crypto) float64 {
+	net := float64(crypto.Upvote) - float64(crypto.Downvote)
+	order := math.Log2(math.Max(math.Abs(net), 1))
+	if net < 0 {
+		order = -order
+	}
+
+	active := crypto.LastVoteAt
+	if active.IsZero() {
+		active = crypto.ID.Timestamp()
+	}
+	return order + active.Sub(r.epoch).Seconds()/r.halfLife.Seconds()
+}
+
+// controversialScore - Total votes raised to the balance between upvotes and downvotes, many
+// evenly split votes score highest and one sided ones score zero
+func controversialScore(crypto model.Crypto) float64 {
+	if crypto.Upvote <= 0 || crypto.Downvote <= 0 {
+		return 0
+	}
+
+	upvote, downvote := float64(crypto.Upvote), float64(crypto.Downvote)
+	balance := math.Min(upvote, downvote) / math.Max(upvote, downvote)
+	return math.Pow(upvote+downvote, balance)
+}
+
+func compareFloat(a float64, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
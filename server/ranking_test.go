@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestWilsonRanking(t *testing.T) {
+	wilson := rankings[SortByWilson]
+
+	assert.Equal(t, 0.0, wilson.Score(model.Crypto{}))
+	assert.InDelta(t, 0.2065, wilson.Score(model.Crypto{Upvote: 1}), 0.0001)
+	assert.InDelta(t, 0.5693, wilson.Score(model.Crypto{Upvote: 600, Downvote: 400}), 0.0001)
+
+	// Test more votes with the same ratio are more certain
+	assert.Greater(t, wilson.Score(model.Crypto{Upvote: 600, Downvote: 400}), wilson.Score(model.Crypto{Upvote: 60, Downvote: 40}))
+	assert.Greater(t, wilson.Score(model.Crypto{Upvote: 60, Downvote: 40}), wilson.Score(model.Crypto{Upvote: 1}))
+}
+
+func TestHotRanking(t *testing.T) {
+	hot := hotRanking{epoch: time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC), halfLife: 12 * time.Hour}
+	voted := time.Date(2021, time.January, 2, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 2.0, hot.Score(model.Crypto{LastVoteAt: voted}))
+	assert.Equal(t, 5.0, hot.Score(model.Crypto{Upvote: 9, Downvote: 1, LastVoteAt: voted}))
+	assert.Equal(t, -1.0, hot.Score(model.Crypto{Upvote: 1, Downvote: 9, LastVoteAt: voted}))
+
+	// Test twice the net votes stay level with a vote one half life later
+	assert.Equal(t,
+		hot.Score(model.Crypto{Upvote: 16, LastVoteAt: voted}),
+		hot.Score(model.Crypto{Upvote: 8, LastVoteAt: voted.Add(12 * time.Hour)}))
+
+	// Test cryptocurrencies without votes use their creation time
+	id := primitive.NewObjectIDFromTimestamp(voted)
+
+	assert.Equal(t, 2.0, hot.Score(model.Crypto{ID: id}))
+}
+
+func TestControversialRanking(t *testing.T) {
+	controversial := rankings[SortByControversial]
+
+	assert.Equal(t, 0.0, controversial.Score(model.Crypto{Upvote: 100}))
+	assert.Equal(t, 0.0, controversial.Score(model.Crypto{Downvote: 100}))
+	assert.Equal(t, 20.0, controversial.Score(model.Crypto{Upvote: 10, Downvote: 10}))
+	assert.InDelta(t, 4.3035, controversial.Score(model.Crypto{Upvote: 3, Downvote: 4}), 0.0001)
+
+	// Test evenly split votes beat one sided ones
+	assert.Greater(t, controversial.Score(model.Crypto{Upvote: 10, Downvote: 10}), controversial.Score(model.Crypto{Upvote: 100, Downvote: 10}))
+}
+
+func TestStoredScores(t *testing.T) {
+	crypto := model.Crypto{ID: primitive.NewObjectID(), Name: "Bitcoin", Description: "Ranked", Upvote: 3, Downvote: 2}
+
+	data, err := bson.Marshal(mongoCryptoDocument{Crypto: crypto, Scores: mongoScores(crypto)})
+	require.Nil(t, err)
+
+	// Test every stored score is a sort key next to the fields of the cryptocurrency
+	document := bson.M{}
+	require.Nil(t, bson.Unmarshal(data, &document))
+	for _, score := range storedScores {
+		assert.Equal(t, rankings[score.field].Score(crypto), document[score.key], score.key)
+		assert.Equal(t, score.key, mongoSortKeys[score.field])
+		assert.Equal(t, score.key, postgresSortKeys[score.field])
+	}
+
+	decoded := model.Crypto{}
+	require.Nil(t, bson.Unmarshal(data, &decoded))
+	assert.Equal(t, crypto, decoded)
+}
@@ -0,0 +1,412 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Rate limit store names of the RATE_LIMIT_STORE environment variable
+const (
+	memoryRateLimitStoreName   = "memory"
+	mongoRateLimitStoreName    = "mongo"
+	postgresRateLimitStoreName = "postgres"
+)
+
+const (
+	// retryAfterMetadataKey - Trailer of rate limited calls, seconds until a token is available
+	retryAfterMetadataKey = "retry-after"
+	// forwardedForMetadataKey - Metadata with the client address of calls relayed by a trusted proxy
+	forwardedForMetadataKey = "x-forwarded-for"
+
+	// defaultTrustedProxies - The REST client dials the server on localhost
+	defaultTrustedProxies = "127.0.0.0/8,::1"
+
+	// sweepInterval - Time between deletions of the buckets refilled to capacity and of expired vote nonces
+	sweepInterval = time.Minute
+)
+
+// rateLimit - Token bucket holding up to count tokens, refilled at count per period
+type rateLimit struct {
+	count  int
+	period time.Duration
+}
+
+// rate - Tokens added per second
+func (l rateLimit) rate() float64 {
+	return float64(l.count) / l.period.Seconds()
+}
+
+// defaultRateLimits - Limits of the vote methods, by method name, overridden by RATE_LIMITS
+var defaultRateLimits = map[string]rateLimit{
+	"UpvoteCrypto":   {count: 60, period: time.Minute},
+	"DownvoteCrypto": {count: 60, period: time.Minute},
+	"RetractVote":    {count: 60, period: time.Minute},
+	"ChangeVote":     {count: 60, period: time.Minute},
+	"VoteStream":     {count: 60, period: time.Minute},
+}
+
+// RateLimitStore - Token buckets of the rate limiter, shared by the replicas using the same store
+type RateLimitStore interface {
+	// Take removes a token from the bucket of key, created full, and reports whether there was one
+	// or else how long until there is
+	Take(ctx context.Context, key string, limit rateLimit, now time.Time) (bool, time.Duration, error)
+	// Refund puts back a token taken from the bucket of key, up to its capacity
+	Refund(ctx context.Context, key string, limit rateLimit) error
+}
+
+// openRateLimitStore - Returns the named store, the shared ones require the storage backend of the same name
+func openRateLimitStore(ctx context.Context, name string, storage Storage) (RateLimitStore, error) {
+	switch name {
+	case memoryRateLimitStoreName:
+		return newMemoryRateLimitStore(), nil
+	case mongoRateLimitStoreName:
+		repository, ok := storage.(*mongoRepository)
+		if !ok {
+			return nil, fmt.Errorf("the %s rate limit store requires the %s storage backend", mongoRateLimitStoreName, mongoBackend)
+		}
+		return newMongoRateLimitStore(ctx, repository)
+	case postgresRateLimitStoreName:
+		repository, ok := storage.(*postgresRepository)
+		if !ok {
+			return nil, fmt.Errorf("the %s rate limit store requires the %s storage backend", postgresRateLimitStoreName, postgresBackend)
+		}
+		return newPostgresRateLimitStore(repository), nil
+	}
+	return nil, fmt.Errorf("unknown rate limit store %q", name)
+}
+
+// parseRateLimits - Overrides defaults with comma separated Method=count/period or Method=off entries
+func parseRateLimits(value string, defaults map[string]rateLimit) (map[string]rateLimit, error) {
+	limits := make(map[string]rateLimit, len(defaults))
+	for method, limit := range defaults {
+		limits[method] = limit
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		fields := strings.SplitN(entry, "=", 2)
+		if len(fields) != 2 || fields[0] == "" {
+			return nil, fmt.Errorf("invalid entry %q", entry)
+		}
+		method, setting := fields[0], fields[1]
+
+		if setting == "off" {
+			delete(limits, method)
+			continue
+		}
+
+		parts := strings.SplitN(setting, "/", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid limit of %s", method)
+		}
+		count, err := strconv.Atoi(parts[0])
+		if err != nil || count < 1 {
+			return nil, fmt.Errorf("invalid count of %s", method)
+		}
+		period, err := time.ParseDuration(parts[1])
+		if err != nil || period <= 0 {
+			return nil, fmt.Errorf("invalid period of %s", method)
+		}
+		limits[method] = rateLimit{count: count, period: period}
+	}
+	return limits, nil
+}
+
+// parseTrustedProxies - Networks of comma separated IP addresses or CIDR ranges
+func parseTrustedProxies(value string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid address %q", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// rateLimiter - Limits the calls of each method separately for every voter, principal and client
+// address, a call needs a token from each of its buckets. Store failures let calls through
+type rateLimiter struct {
+	store RateLimitStore
+	// limits - Limit of each rate limited method, by gRPC full method name
+	limits map[string]rateLimit
+	// trustedProxies - Peers whose x-forwarded-for metadata names the client address
+	trustedProxies []*net.IPNet
+
+	now func() time.Time
+}
+
+func newRateLimiter(store RateLimitStore, limits map[string]rateLimit, trustedProxies []*net.IPNet) *rateLimiter {
+	fullMethodLimits := make(map[string]rateLimit, len(limits))
+	for method, limit := range limits {
+		fullMethodLimits[upvoteSystemMethod(method)] = limit
+	}
+	return &rateLimiter{store: store, limits: fullMethodLimits, trustedProxies: trustedProxies, now: time.Now}
+}
+
+// keys - Buckets of a call of method with request req
+func (l *rateLimiter) keys(ctx context.Context, method string, req interface{}) []string {
+	var keys []string
+	if voter, ok := req.(interface{ GetVoterId() string }); ok {
+		// A voter the caller may not vote as fails the call afterwards, no bucket for it
+		voterID, _ := voterIDFromContext(ctx, voter.GetVoterId())
+		// Signed votes are cast by their key, whatever voter they request
+		if signed, ok := req.(signedVoteRequest); ok && signed.GetSignature() != nil {
+			voterID, _ = signingVoterID(signed.GetSignature())
+		}
+		if voterID != "" {
+			keys = append(keys, method+"|voter:"+voterID)
+		}
+	}
+	if principal, ok := principalFromContext(ctx); ok {
+		keys = append(keys, method+"|"+principal.Method+":"+principal.ID)
+	}
+	if ip := l.clientIP(ctx); ip != "" {
+		keys = append(keys, method+"|ip:"+ip)
+	}
+	return keys
+}
+
+// clientIP - Address of the peer of ctx or, when it is a trusted proxy, the last forwarded one
+func (l *rateLimiter) clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+
+	if ip := net.ParseIP(host); ip == nil || !l.trusted(ip) {
+		return host
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	forwarded := md.Get(forwardedForMetadataKey)
+	if len(forwarded) == 0 {
+		return host
+	}
+	addresses := strings.Split(forwarded[len(forwarded)-1], ",")
+	if last := strings.TrimSpace(addresses[len(addresses)-1]); net.ParseIP(last) != nil {
+		return last
+	}
+	return host
+}
+
+func (l *rateLimiter) trusted(ip net.IP) bool {
+	for _, network := range l.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// wait - Zero when every bucket of the call had a token, or else the longest wait for one. A
+// rejected call gets back the tokens it took from its other buckets
+func (l *rateLimiter) wait(ctx context.Context, method string, limit rateLimit, req interface{}) time.Duration {
+	now := l.now()
+	var longest time.Duration
+	var taken []string
+	for _, key := range l.keys(ctx, method, req) {
+		allowed, retryAfter, err := l.store.Take(ctx, key, limit, now)
+		if err != nil {
+			log.Printf("Error: rate limit of %s: %v", key, err)
+			continue
+		}
+		if allowed {
+			taken = append(taken, key)
+		} else if retryAfter > longest {
+			longest = retryAfter
+		}
+	}
+
+	if longest > 0 {
+		for _, key := range taken {
+			if err := l.store.Refund(ctx, key, limit); err != nil {
+				log.Printf("Error: rate limit refund of %s: %v", key, err)
+			}
+		}
+	}
+	return longest
+}
+
+// rateLimitError - ResourceExhausted status of a call rejected for retryAfter, with the trailer
+// metadata announcing it in whole seconds
+func rateLimitError(retryAfter time.Duration) (metadata.MD, error) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	trailer := metadata.Pairs(retryAfterMetadataKey, strconv.Itoa(seconds))
+	return trailer, status.Errorf(codes.ResourceExhausted, "Rate limit exceeded, retry in %ds", seconds)
+}
+
+// unaryInterceptor - Rejects the calls of rate limited methods exceeding one of their buckets
+func (l *rateLimiter) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	limit, limited := l.limits[info.FullMethod]
+	if !limited {
+		return handler(ctx, req)
+	}
+
+	if retryAfter := l.wait(ctx, info.FullMethod, limit, req); retryAfter > 0 {
+		trailer, err := rateLimitError(retryAfter)
+		grpc.SetTrailer(ctx, trailer)
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// streamInterceptor - Limits every message received by rate limited streams, the first one
+// exceeding a bucket ends the stream
+func (l *rateLimiter) streamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	limit, limited := l.limits[info.FullMethod]
+	if !limited {
+		return handler(srv, stream)
+	}
+	return handler(srv, &rateLimitedStream{ServerStream: stream, limiter: l, method: info.FullMethod, limit: limit})
+}
+
+// rateLimitedStream - ServerStream taking a token for each received message
+type rateLimitedStream struct {
+	grpc.ServerStream
+	limiter *rateLimiter
+	method  string
+	limit   rateLimit
+}
+
+func (s *rateLimitedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	if retryAfter := s.limiter.wait(s.Context(), s.method, s.limit, m); retryAfter > 0 {
+		trailer, err := rateLimitError(retryAfter)
+		s.SetTrailer(trailer)
+		return err
+	}
+	return nil
+}
+
+// sweeper - Spaces out the deletions of idle buckets and expired nonces
+type sweeper struct {
+	mutex sync.Mutex
+	last  time.Time
+}
+
+// due - Reports whether a sweep is due at now, and if so records it
+func (s *sweeper) due(now time.Time) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if now.Sub(s.last) < sweepInterval {
+		return false
+	}
+	s.last = now
+	return true
+}
+
+// tokenBucket - Tokens left at the last update
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+	// full - Time the bucket is refilled to capacity, it can then be deleted
+	full time.Time
+}
+
+// take - Refills the bucket up to now and removes a token, or returns the wait for one
+func (b *tokenBucket) take(limit rateLimit, now time.Time) (bool, time.Duration) {
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = math.Min(float64(limit.count), b.tokens+elapsed.Seconds()*limit.rate())
+		b.updated = now
+	}
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	b.full = b.updated.Add(time.Duration((float64(limit.count) - b.tokens) / limit.rate() * float64(time.Second)))
+
+	if allowed {
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / limit.rate() * float64(time.Second))
+}
+
+// refund - Puts back a token, up to the capacity of the bucket
+func (b *tokenBucket) refund(limit rateLimit) {
+	b.tokens = math.Min(float64(limit.count), b.tokens+1)
+	b.full = b.updated.Add(time.Duration((float64(limit.count) - b.tokens) / limit.rate() * float64(time.Second)))
+}
+
+// memoryRateLimitStore - Buckets of this process only, each replica limits the calls it serves
+type memoryRateLimitStore struct {
+	mutex   sync.Mutex
+	buckets map[string]*tokenBucket
+	sweeper sweeper
+}
+
+func newMemoryRateLimitStore() *memoryRateLimitStore {
+	return &memoryRateLimitStore{buckets: make(map[string]*tokenBucket)}
+}
+
+func (s *memoryRateLimitStore) Take(ctx context.Context, key string, limit rateLimit, now time.Time) (bool, time.Duration, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.sweeper.due(now) {
+		for bucketKey, bucket := range s.buckets {
+			if !now.Before(bucket.full) {
+				delete(s.buckets, bucketKey)
+			}
+		}
+	}
+
+	bucket, found := s.buckets[key]
+	if !found {
+		bucket = &tokenBucket{tokens: float64(limit.count), updated: now}
+		s.buckets[key] = bucket
+	}
+	allowed, retryAfter := bucket.take(limit, now)
+	return allowed, retryAfter, nil
+}
+
+func (s *memoryRateLimitStore) Refund(ctx context.Context, key string, limit rateLimit) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// Swept buckets are full already
+	if bucket, found := s.buckets[key]; found {
+		bucket.refund(limit)
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"net"
+	"testing"
+	"time"
+
+	upvoteSystem "github.com/RomuloSiebra/CryptoUpvoteSystem/proto/UpvoteSystem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestMemoryRateLimitStore(t *testing.T) {
+	store := newMemoryRateLimitStore()
+	limit := rateLimit{count: 2, period: time.Minute}
+	now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	// Test new buckets start full
+	for i := 0; i < 2; i++ {
+		allowed, _, err := store.Take(context.Background(), "voter:alice", limit, now)
+		require.Nil(t, err)
+		assert.True(t, allowed)
+	}
+
+	allowed, retryAfter, err := store.Take(context.Background(), "voter:alice", limit, now)
+	require.Nil(t, err)
+	assert.False(t, allowed)
+	assert.Equal(t, 30*time.Second, retryAfter)
+
+	// Test buckets are independent
+	allowed, _, err = store.Take(context.Background(), "voter:bob", limit, now)
+	require.Nil(t, err)
+	assert.True(t, allowed)
+
+	// Test a token is added every period / count
+	allowed, retryAfter, err = store.Take(context.Background(), "voter:alice", limit, now.Add(20*time.Second))
+	require.Nil(t, err)
+	assert.False(t, allowed)
+	assert.Equal(t, 10*time.Second, retryAfter)
+
+	allowed, _, err = store.Take(context.Background(), "voter:alice", limit, now.Add(30*time.Second))
+	require.Nil(t, err)
+	assert.True(t, allowed)
+
+	// Test refunds put a token back, up to the capacity
+	require.Nil(t, store.Refund(context.Background(), "voter:alice", limit))
+	allowed, _, err = store.Take(context.Background(), "voter:alice", limit, now.Add(30*time.Second))
+	require.Nil(t, err)
+	assert.True(t, allowed)
+
+	require.Nil(t, store.Refund(context.Background(), "voter:bob", limit))
+	require.Nil(t, store.Refund(context.Background(), "voter:bob", limit))
+	assert.Equal(t, float64(2), store.buckets["voter:bob"].tokens)
+	require.Nil(t, store.Refund(context.Background(), "voter:dave", limit))
+	assert.NotContains(t, store.buckets, "voter:dave")
+
+	// Test refilled buckets are deleted by the next sweep
+	_, _, err = store.Take(context.Background(), "voter:carol", limit, now.Add(2*time.Minute))
+	require.Nil(t, err)
+	assert.NotContains(t, store.buckets, "voter:alice")
+	assert.NotContains(t, store.buckets, "voter:bob")
+	assert.Contains(t, store.buckets, "voter:carol")
+}
+
+func TestSharedRateLimitStore(t *testing.T) {
+	storage := setupDB()
+	defer clearDB()
+
+	var name string
+	switch storage.(type) {
+	case *mongoRepository:
+		name = mongoRateLimitStoreName
+	case *postgresRepository:
+		name = postgresRateLimitStoreName
+	default:
+		t.Skip("requires STORAGE_BACKEND=mongo or postgres")
+	}
+
+	store, err := openRateLimitStore(context.Background(), name, storage)
+	require.Nil(t, err)
+
+	key := "voter:" + primitive.NewObjectID().Hex()
+	limit := rateLimit{count: 2, period: time.Minute}
+	now := time.Now().Truncate(time.Millisecond)
+
+	for i := 0; i < 2; i++ {
+		allowed, _, err := store.Take(context.Background(), key, limit, now)
+		require.Nil(t, err)
+		assert.True(t, allowed)
+	}
+
+	allowed, retryAfter, err := store.Take(context.Background(), key, limit, now.Add(20*time.Second))
+	require.Nil(t, err)
+	assert.False(t, allowed)
+	assert.InDelta(t, float64(10*time.Second), float64(retryAfter), float64(time.Millisecond))
+
+	allowed, _, err = store.Take(context.Background(), key, limit, now.Add(30*time.Second))
+	require.Nil(t, err)
+	assert.True(t, allowed)
+
+	// Test refunds put a token back
+	require.Nil(t, store.Refund(context.Background(), key, limit))
+	allowed, _, err = store.Take(context.Background(), key, limit, now.Add(30*time.Second))
+	require.Nil(t, err)
+	assert.True(t, allowed)
+}
+
+func TestParseRateLimits(t *testing.T) {
+	limits, err := parseRateLimits("UpvoteCrypto=5/1s, VoteStream=off,SearchCrypto=100/1m", defaultRateLimits)
+	require.Nil(t, err)
+
+	assert.Equal(t, rateLimit{count: 5, period: time.Second}, limits["UpvoteCrypto"])
+	assert.Equal(t, defaultRateLimits["DownvoteCrypto"], limits["DownvoteCrypto"])
+	assert.Equal(t, rateLimit{count: 100, period: time.Minute}, limits["SearchCrypto"])
+	assert.NotContains(t, limits, "VoteStream")
+	assert.Contains(t, defaultRateLimits, "VoteStream")
+
+	for _, value := range []string{"UpvoteCrypto", "UpvoteCrypto=5", "UpvoteCrypto=0/1s", "UpvoteCrypto=5/0s", "=5/1s"} {
+		_, err := parseRateLimits(value, defaultRateLimits)
+		assert.NotNil(t, err, value)
+	}
+}
+
+// peerContext - Context of a call from address with the pairs metadata
+func peerContext(address string, pairs ...string) context.Context {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(pairs...))
+	addr, _ := net.ResolveTCPAddr("tcp", address)
+	return peer.NewContext(ctx, &peer.Peer{Addr: addr})
+}
+
+func TestRateLimiterKeys(t *testing.T) {
+	proxies, err := parseTrustedProxies(defaultTrustedProxies)
+	require.Nil(t, err)
+	limiter := newRateLimiter(newMemoryRateLimitStore(), defaultRateLimits, proxies)
+	method := upvoteSystemMethod("UpvoteCrypto")
+
+	ctx := withPrincipal(peerContext("203.0.113.7:5000"), Principal{ID: "dashboard", Method: authMethodAPIKey})
+	assert.Equal(t, []string{
+		method + "|voter:dashboard",
+		method + "|api-key:dashboard",
+		method + "|ip:203.0.113.7",
+	}, limiter.keys(ctx, method, &upvoteSystem.UpvoteCryptoRequest{}))
+
+	// Test a voter the principal may not vote as gets no bucket
+	assert.Equal(t, []string{
+		method + "|api-key:dashboard",
+		method + "|ip:203.0.113.7",
+	}, limiter.keys(ctx, method, &upvoteSystem.UpvoteCryptoRequest{VoterId: "alice"}))
+
+	// Test the voter-id metadata and the address forwarded by a trusted proxy
+	ctx = peerContext("127.0.0.1:5000", "voter-id", "bob", "x-forwarded-for", "198.51.100.1, 203.0.113.9")
+	assert.Equal(t, []string{method + "|voter:bob", method + "|ip:203.0.113.9"}, limiter.keys(ctx, method, &upvoteSystem.UpvoteCryptoRequest{}))
+
+	// Test untrusted peers can't forward an address
+	ctx = peerContext("203.0.113.7:5000", "x-forwarded-for", "198.51.100.1")
+	assert.Equal(t, []string{method + "|ip:203.0.113.7"}, limiter.keys(ctx, method, &upvoteSystem.UpvoteCryptoRequest{}))
+
+	// Test signed votes are limited by their key, whatever voter they request
+	publicKey := make([]byte, 32)
+	signature := &upvoteSystem.VoteSignature{KeyType: upvoteSystem.KeyType_KEY_ED25519, PublicKey: publicKey}
+	ctx = peerContext("203.0.113.7:5000", "voter-id", "bob")
+	assert.Equal(t, []string{
+		method + "|voter:ed25519:" + hex.EncodeToString(publicKey),
+		method + "|ip:203.0.113.7",
+	}, limiter.keys(ctx, method, &upvoteSystem.UpvoteCryptoRequest{VoterId: "alice", Signature: signature}))
+}
+
+// trailerStream - ServerTransportStream recording the trailer set by handlers
+type trailerStream struct {
+	grpc.ServerTransportStream
+	trailer metadata.MD
+}
+
+func (s *trailerStream) Method() string {
+	return ""
+}
+
+func (s *trailerStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+func TestRateLimiterUnaryInterceptor(t *testing.T) {
+	limiter := newRateLimiter(newMemoryRateLimitStore(), map[string]rateLimit{"UpvoteCrypto": {count: 1, period: 10 * time.Second}}, nil)
+	now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	upvote := &grpc.UnaryServerInfo{FullMethod: upvoteSystemMethod("UpvoteCrypto")}
+	read := &grpc.UnaryServerInfo{FullMethod: upvoteSystemMethod("ReadCryptoByID")}
+
+	response, err := limiter.unaryInterceptor(peerContext("203.0.113.7:5000"), &upvoteSystem.UpvoteCryptoRequest{VoterId: "alice"}, upvote, handler)
+	require.Nil(t, err)
+	assert.Equal(t, "ok", response)
+
+	// Test changing the voter doesn't escape the limit of the address
+	stream := &trailerStream{}
+	ctx := grpc.NewContextWithServerTransportStream(peerContext("203.0.113.7:5000"), stream)
+	_, err = limiter.unaryInterceptor(ctx, &upvoteSystem.UpvoteCryptoRequest{VoterId: "bob"}, upvote, handler)
+	require.NotNil(t, err)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, "Rate limit exceeded, retry in 10s", status.Convert(err).Message())
+	assert.Equal(t, []string{"10"}, stream.trailer.Get(retryAfterMetadataKey))
+
+	// Test the rejected call didn't use up the bucket of its voter
+	_, err = limiter.unaryInterceptor(peerContext("198.51.100.4:5000"), &upvoteSystem.UpvoteCryptoRequest{VoterId: "bob"}, upvote, handler)
+	assert.Nil(t, err)
+
+	// Test methods without limit aren't limited
+	_, err = limiter.unaryInterceptor(peerContext("203.0.113.7:5000"), &upvoteSystem.ReadCryptoByIDRequest{}, read, handler)
+	assert.Nil(t, err)
+
+	now = now.Add(10 * time.Second)
+	_, err = limiter.unaryInterceptor(peerContext("203.0.113.7:5000"), &upvoteSystem.UpvoteCryptoRequest{VoterId: "carol"}, upvote, handler)
+	assert.Nil(t, err)
+}
+
+// recvStream - ServerStream receiving vote commands for the same voter
+type recvStream struct {
+	contextStream
+	trailer metadata.MD
+}
+
+func (s *recvStream) RecvMsg(m interface{}) error {
+	m.(*upvoteSystem.VoteCommand).VoterId = "alice"
+	return nil
+}
+
+func (s *recvStream) SetTrailer(md metadata.MD) {
+	s.trailer = md
+}
+
+func TestRateLimiterStreamInterceptor(t *testing.T) {
+	limiter := newRateLimiter(newMemoryRateLimitStore(), map[string]rateLimit{"VoteStream": {count: 2, period: time.Minute}}, nil)
+
+	received := 0
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		for {
+			if err := stream.RecvMsg(&upvoteSystem.VoteCommand{}); err != nil {
+				return err
+			}
+			received++
+		}
+	}
+
+	stream := &recvStream{contextStream: contextStream{ctx: context.Background()}}
+	err := limiter.streamInterceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: upvoteSystemMethod("VoteStream")}, handler)
+	require.NotNil(t, err)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, 2, received)
+	assert.Equal(t, []string{"30"}, stream.trailer.Get(retryAfterMetadataKey))
+}
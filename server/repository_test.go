@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRepositoryUniqueness(t *testing.T) {
+	storage := setupDB()
+	defer clearDB()
+	ctx := context.Background()
+
+	launchDate := time.Date(2009, time.January, 3, 0, 0, 0, 0, time.UTC)
+	bitcoin, err := storage.Create(ctx, model.Crypto{
+		Name:        "Bitcoin",
+		Description: "The first cryptocurrency",
+		Symbol:      "BTC",
+		Website:     "https://bitcoin.org",
+		LogoURL:     "https://bitcoin.org/logo.svg",
+		LaunchDate:  launchDate,
+		Tags:        []string{"pow", "store-of-value"},
+	})
+	require.Nil(t, err)
+
+	ethereum, err := storage.Create(ctx, model.Crypto{Name: "Ethereum", Description: "Smart contracts platform"})
+	require.Nil(t, err)
+
+	// Test names and symbols are unique ignoring case
+	_, err = storage.Create(ctx, model.Crypto{Name: "BITCOIN", Description: "Copy"})
+
+	assert.Equal(t, ErrAlreadyExists, err)
+
+	_, err = storage.Create(ctx, model.Crypto{Name: "Bitcoin Cash", Description: "Fork", Symbol: "btc"})
+
+	assert.Equal(t, ErrAlreadyExists, err)
+
+	_, err = storage.Update(ctx, model.Crypto{ID: ethereum.ID, Name: "bitcoin", Description: "Copy"})
+
+	assert.Equal(t, ErrAlreadyExists, err)
+
+	_, err = storage.Update(ctx, model.Crypto{ID: ethereum.ID, Name: "Ethereum", Description: "Copy", Symbol: "Btc"})
+
+	assert.Equal(t, ErrAlreadyExists, err)
+
+	// Test lookup by symbol ignoring case
+	found, err := storage.GetBySymbol(ctx, "btc")
+
+	require.Nil(t, err)
+	assert.Equal(t, bitcoin.ID, found.ID)
+	assert.Equal(t, "https://bitcoin.org", found.Website)
+	assert.Equal(t, "https://bitcoin.org/logo.svg", found.LogoURL)
+	assert.True(t, launchDate.Equal(found.LaunchDate))
+	assert.Equal(t, []string{"pow", "store-of-value"}, found.Tags)
+
+	_, err = storage.GetBySymbol(ctx, "ETH")
+
+	assert.Equal(t, ErrNotFound, err)
+
+	_, err = storage.GetBySymbol(ctx, "")
+
+	assert.Equal(t, ErrNotFound, err)
+
+	// Test renaming keeps the votes and frees the previous name and symbol
+	_, err = storage.UpdateVote(ctx, bitcoin.ID, "voter", func(model.VoteDirection) (model.VoteDirection, error) {
+		return model.Upvote, nil
+	})
+	require.Nil(t, err)
+
+	updated, err := storage.Update(ctx, model.Crypto{ID: bitcoin.ID, Name: "Bitcoin Core", Description: "The first cryptocurrency", Symbol: "XBT"})
+
+	require.Nil(t, err)
+	assert.Equal(t, "Bitcoin Core", updated.Name)
+	assert.Equal(t, "XBT", updated.Symbol)
+	assert.Empty(t, updated.Website)
+	assert.Empty(t, updated.Tags)
+	assert.Equal(t, int32(1), updated.Upvote)
+
+	_, err = storage.GetBySymbol(ctx, "BTC")
+
+	assert.Equal(t, ErrNotFound, err)
+
+	_, err = storage.Create(ctx, model.Crypto{Name: "bitcoin", Description: "Reused name", Symbol: "BTC"})
+
+	require.Nil(t, err)
+
+	// Test deleting frees the name and symbol
+	require.Nil(t, storage.Delete(ctx, updated.ID))
+
+	_, err = storage.Create(ctx, model.Crypto{Name: "BITCOIN CORE", Description: "Reused name", Symbol: "xbt"})
+
+	require.Nil(t, err)
+
+	// Test case changes of the own name and symbol are allowed
+	_, err = storage.Update(ctx, model.Crypto{ID: ethereum.ID, Name: "ETHEREUM", Description: "Smart contracts platform", Symbol: "ETH"})
+
+	require.Nil(t, err)
+
+	_, err = storage.Update(ctx, model.Crypto{ID: ethereum.ID, Name: "Ethereum", Description: "Smart contracts platform", Symbol: "eth"})
+
+	require.Nil(t, err)
+}
+
+func TestRepositoryUseNonce(t *testing.T) {
+	storage := setupDB()
+	defer clearDB()
+	ctx := context.Background()
+	expires := time.Now().Add(time.Minute)
+
+	require.Nil(t, storage.UseNonce(ctx, "ed25519:alice", "nonce-1", expires))
+
+	// Test nonces are single use per key
+	assert.Equal(t, ErrNonceUsed, storage.UseNonce(ctx, "ed25519:alice", "nonce-1", expires))
+	assert.Nil(t, storage.UseNonce(ctx, "ed25519:alice", "nonce-2", expires))
+	assert.Nil(t, storage.UseNonce(ctx, "ed25519:bob", "nonce-1", expires))
+
+	// Test expired nonces can be used again
+	require.Nil(t, storage.UseNonce(ctx, "ed25519:carol", "nonce-1", time.Now().Add(-time.Second)))
+	assert.Nil(t, storage.UseNonce(ctx, "ed25519:carol", "nonce-1", expires))
+}
+
+func TestResolveCaseConflicts(t *testing.T) {
+	ids := []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()}
+	cryptos := []model.Crypto{
+		{ID: ids[0], Name: "bitcoin", Symbol: "BTC"},
+		{ID: ids[1], Name: "Bitcoin", Symbol: "btc"},
+		{ID: ids[2], Name: "Ethereum", Symbol: "BTC"},
+		{ID: ids[3], Name: "Litecoin", Symbol: "LTC"},
+	}
+
+	// Test the oldest keeps its name and symbol, newer ones are renamed and lose the symbol
+	assert.Equal(t, []model.Crypto{
+		{ID: ids[1], Name: "Bitcoin (" + ids[1].Hex() + ")"},
+		{ID: ids[2], Name: "Ethereum"},
+	}, resolveCaseConflicts(cryptos))
+
+	assert.Empty(t, resolveCaseConflicts(cryptos[2:]))
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	upvoteSystem "github.com/RomuloSiebra/CryptoUpvoteSystem/proto/UpvoteSystem"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultSearchLimit - Number of search results when the request limit is unset
+	defaultSearchLimit = 20
+	// maxSearchLimit - Most search results, bigger limits are rejected
+	maxSearchLimit = 100
+
+	// nameWeight and descriptionWeight - Relevance multiplier of a word found in each field
+	nameWeight        = 2.0
+	descriptionWeight = 1.0
+
+	// exactMatch, prefixMatch and fuzzyMatch - Relevance of a query term equal to a word,
+	// starting it or one typo away from it
+	exactMatch  = 1.0
+	prefixMatch = 0.7
+	fuzzyMatch  = 0.4
+
+	// fuzzyMinLength - Shortest query term matched with a typo, shorter ones match too many words
+	fuzzyMinLength = 4
+)
+
+// searchTerms - Lowercase words of text, split on anything but letters and digits
+func searchTerms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// termMatch - Relevance of word for a query term, zero when it doesn't match
+func termMatch(term string, word string) float64 {
+	switch {
+	case word == term:
+		return exactMatch
+	case strings.HasPrefix(word, term):
+		return prefixMatch
+	case len([]rune(term)) >= fuzzyMinLength && withinOneEdit([]rune(term), []rune(word)):
+		return fuzzyMatch
+	}
+	return 0
+}
+
+// withinOneEdit - Reports whether a becomes b by inserting, deleting or replacing at most one rune
+func withinOneEdit(a []rune, b []rune) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	if len(b)-len(a) > 1 {
+		return false
+	}
+
+	i := 0
+	for i < len(a) && a[i] == b[i] {
+		i++
+	}
+	if i == len(a) {
+		return true
+	}
+
+	// Skip the first differing rune, of both words when replaced or of the longer one when inserted
+	if len(a) == len(b) {
+		return string(a[i+1:]) == string(b[i+1:])
+	}
+	return string(a[i:]) == string(b[i+1:])
+}
+
+// bestMatch - Highest termMatch of term among words
+func bestMatch(term string, words []string) float64 {
+	best := 0.0
+	for _, word := range words {
+		if match := termMatch(term, word); match > best {
+			best = match
+		}
+	}
+	return best
+}
+
+// wordsRelevance - Sum over the query terms of their best match, name words weigh more than description ones
+func wordsRelevance(terms []string, name []string, description []string) float64 {
+	relevance := 0.0
+	for _, term := range terms {
+		nameMatch := nameWeight * bestMatch(term, name)
+		descriptionMatch := descriptionWeight * bestMatch(term, description)
+		if nameMatch > descriptionMatch {
+			relevance += nameMatch
+		} else {
+			relevance += descriptionMatch
+		}
+	}
+	return relevance
+}
+
+// cryptoRelevance - wordsRelevance of the name and description of crypto
+func cryptoRelevance(terms []string, crypto model.Crypto) float64 {
+	return wordsRelevance(terms, searchTerms(crypto.Name), searchTerms(crypto.Description))
+}
+
+// rankSearchResults - Sorts results by relevance, then creation, and keeps the first limit
+func rankSearchResults(results []SearchResult, limit int) []SearchResult {
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Relevance != results[j].Relevance {
+			return results[i].Relevance > results[j].Relevance
+		}
+		return bytes.Compare(results[i].Crypto.ID[:], results[j].Crypto.ID[:]) < 0
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+func (s *server) SearchCrypto(ctx context.Context, request *upvoteSystem.SearchCryptoRequest) (*upvoteSystem.SearchCryptoResponse, error) {
+	if len(searchTerms(request.GetQuery())) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid query")
+	}
+
+	limit := int(request.GetLimit())
+	if limit < 0 || limit > maxSearchLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid limit")
+	}
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+
+	results, err := s.search.Search(ctx, request.GetQuery(), limit)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+
+	response := &upvoteSystem.SearchCryptoResponse{}
+	for _, result := range results {
+		response.Results = append(response.Results, &upvoteSystem.SearchResult{
+			Crypto:    toCryptocurrency(result.Crypto),
+			Relevance: result.Relevance,
+		})
+	}
+	return response, nil
+}
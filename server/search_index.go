@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"sync"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// searchIndex - SearchRepository of backends without full-text search. Wraps their CryptoRepository
+// and keeps an in-process inverted index of the writes going through it, loaded from storage on the
+// first search. Writes of other replicas sharing the storage are only seen after a restart
+type searchIndex struct {
+	CryptoRepository
+
+	mutex  sync.RWMutex
+	loaded bool
+	// postings - Cryptocurrencies containing each word
+	postings  map[string]map[primitive.ObjectID]struct{}
+	documents map[primitive.ObjectID]searchDocument
+}
+
+// searchDocument - Indexed words of a cryptocurrency
+type searchDocument struct {
+	name        []string
+	description []string
+}
+
+func newSearchIndex(repository CryptoRepository) *searchIndex {
+	return &searchIndex{
+		CryptoRepository: repository,
+		postings:         make(map[string]map[primitive.ObjectID]struct{}),
+		documents:        make(map[primitive.ObjectID]searchDocument),
+	}
+}
+
+func (i *searchIndex) Create(ctx context.Context, crypto model.Crypto) (model.Crypto, error) {
+	created, err := i.CryptoRepository.Create(ctx, crypto)
+	if err != nil {
+		return model.Crypto{}, err
+	}
+	i.refresh(ctx, created.ID)
+	return created, nil
+}
+
+func (i *searchIndex) Update(ctx context.Context, crypto model.Crypto) (model.Crypto, error) {
+	updated, err := i.CryptoRepository.Update(ctx, crypto)
+	if err != nil {
+		return model.Crypto{}, err
+	}
+	i.refresh(ctx, crypto.ID)
+	return updated, nil
+}
+
+func (i *searchIndex) Delete(ctx context.Context, id primitive.ObjectID) error {
+	if err := i.CryptoRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	i.refresh(ctx, id)
+	return nil
+}
+
+// refresh - Indexes the stored state of a written cryptocurrency. Reading it back under the lock
+// keeps the index right when concurrent writes of the same cryptocurrency finish out of order,
+// when it can't be read the next search loads the whole index again
+func (i *searchIndex) refresh(ctx context.Context, id primitive.ObjectID) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	// The first search loads every write done until then
+	if !i.loaded {
+		return
+	}
+
+	crypto, err := i.CryptoRepository.GetByID(ctx, id)
+	switch {
+	case err == ErrNotFound:
+		i.remove(id)
+	case err != nil:
+		i.loaded = false
+	default:
+		i.put(crypto)
+	}
+}
+
+// load - Indexes every stored cryptocurrency unless it was already done
+func (i *searchIndex) load(ctx context.Context) error {
+	i.mutex.RLock()
+	loaded := i.loaded
+	i.mutex.RUnlock()
+	if loaded {
+		return nil
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	if i.loaded {
+		return nil
+	}
+
+	cryptos, err := i.CryptoRepository.List(ctx, ListQuery{})
+	if err != nil {
+		return err
+	}
+
+	i.postings = make(map[string]map[primitive.ObjectID]struct{})
+	i.documents = make(map[primitive.ObjectID]searchDocument)
+	for _, crypto := range cryptos {
+		i.put(crypto)
+	}
+	i.loaded = true
+	return nil
+}
+
+func (i *searchIndex) put(crypto model.Crypto) {
+	i.remove(crypto.ID)
+
+	document := searchDocument{name: searchTerms(crypto.Name), description: searchTerms(crypto.Description)}
+	i.documents[crypto.ID] = document
+
+	for _, words := range [][]string{document.name, document.description} {
+		for _, word := range words {
+			if i.postings[word] == nil {
+				i.postings[word] = make(map[primitive.ObjectID]struct{})
+			}
+			i.postings[word][crypto.ID] = struct{}{}
+		}
+	}
+}
+
+func (i *searchIndex) remove(id primitive.ObjectID) {
+	document, found := i.documents[id]
+	if !found {
+		return
+	}
+	delete(i.documents, id)
+
+	for _, words := range [][]string{document.name, document.description} {
+		for _, word := range words {
+			delete(i.postings[word], id)
+			if len(i.postings[word]) == 0 {
+				delete(i.postings, word)
+			}
+		}
+	}
+}
+
+// Search matches every query term against the indexed words, then reads the current counters of
+// the best matches from storage
+func (i *searchIndex) Search(ctx context.Context, query string, limit int) ([]SearchResult, error) {
+	if err := i.load(ctx); err != nil {
+		return nil, err
+	}
+
+	terms := searchTerms(query)
+
+	i.mutex.RLock()
+	candidates := make(map[primitive.ObjectID]struct{})
+	for _, term := range terms {
+		for word, ids := range i.postings {
+			if termMatch(term, word) == 0 {
+				continue
+			}
+			for id := range ids {
+				candidates[id] = struct{}{}
+			}
+		}
+	}
+
+	results := make([]SearchResult, 0, len(candidates))
+	for id := range candidates {
+		document := i.documents[id]
+		results = append(results, SearchResult{
+			Crypto:    model.Crypto{ID: id},
+			Relevance: wordsRelevance(terms, document.name, document.description),
+		})
+	}
+	i.mutex.RUnlock()
+
+	results = rankSearchResults(results, limit)
+
+	found := results[:0]
+	for _, result := range results {
+		crypto, err := i.CryptoRepository.GetByID(ctx, result.Crypto.ID)
+		if err == ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.Crypto = crypto
+		found = append(found, result)
+	}
+	return found, nil
+}
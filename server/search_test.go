@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTermMatch(t *testing.T) {
+	assert.Equal(t, exactMatch, termMatch("bitcoin", "bitcoin"))
+	assert.Equal(t, prefixMatch, termMatch("bit", "bitcoin"))
+
+	// Test one typo is tolerated on longer terms
+	assert.Equal(t, fuzzyMatch, termMatch("bitcon", "bitcoin"))
+	assert.Equal(t, fuzzyMatch, termMatch("bitcoiin", "bitcoin"))
+	assert.Equal(t, fuzzyMatch, termMatch("etherium", "ethereum"))
+	assert.Equal(t, 0.0, termMatch("btcon", "bitcoin"))
+	assert.Equal(t, 0.0, termMatch("dog", "doe"))
+}
+
+func TestSearchTerms(t *testing.T) {
+	assert.Equal(t, []string{"the", "first", "crypto", "currency", "2009"}, searchTerms("The first crypto-currency (2009)"))
+	assert.Equal(t, []string{"ßtc", "éther"}, searchTerms("  ßTC, Éther!"))
+	assert.Empty(t, searchTerms(" -- "))
+}
+
+func TestSearchPattern(t *testing.T) {
+	pattern := regexp.MustCompile("(?i)" + searchPattern(searchTerms("bitcon eth")))
+
+	for _, text := range []string{"Bitcoin", "The bitcoin fork", "ETHEREUM", "Smart (eth) contracts", "bitcan"} {
+		assert.True(t, pattern.MatchString(text), text)
+	}
+	for _, text := range []string{"Dogecoin", "Tether", "bitten"} {
+		assert.False(t, pattern.MatchString(text), text)
+	}
+}
+
+func TestSearchIndex(t *testing.T) {
+	ctx := context.Background()
+	repository := newMemoryRepository()
+
+	// Test cryptocurrencies stored before the index was created are loaded on the first search
+	bitcoin, err := repository.Create(ctx, model.Crypto{Name: "Bitcoin", Description: "The first cryptocurrency"})
+	require.Nil(t, err)
+
+	index := newSearchIndex(repository)
+
+	ethereum, err := index.Create(ctx, model.Crypto{Name: "Ethereum", Description: "Smart contracts, pays fees in ether"})
+	require.Nil(t, err)
+
+	results, err := index.Search(ctx, "first", 10)
+
+	require.Nil(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, bitcoin.ID, results[0].Crypto.ID)
+
+	// Test name matches rank before description ones
+	litecoin, err := index.Create(ctx, model.Crypto{Name: "Litecoin", Description: "Lighter than bitcoin"})
+	require.Nil(t, err)
+
+	results, err = index.Search(ctx, "bitcoin", 10)
+
+	require.Nil(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, []string{"Bitcoin", "Litecoin"}, []string{results[0].Crypto.Name, results[1].Crypto.Name})
+	assert.Equal(t, nameWeight*exactMatch, results[0].Relevance)
+	assert.Equal(t, descriptionWeight*exactMatch, results[1].Relevance)
+
+	// Test the limit and current counters
+	_, err = index.UpdateVote(ctx, litecoin.ID, "voter", func(model.VoteDirection) (model.VoteDirection, error) {
+		return model.Upvote, nil
+	})
+	require.Nil(t, err)
+
+	results, err = index.Search(ctx, "coin lite", 1)
+
+	require.Nil(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "Litecoin", results[0].Crypto.Name)
+	assert.Equal(t, int32(1), results[0].Crypto.Upvote)
+
+	// Test updates and deletions
+	_, err = index.Update(ctx, model.Crypto{ID: ethereum.ID, Name: "Ether", Description: "Smart contracts"})
+	require.Nil(t, err)
+
+	results, err = index.Search(ctx, "fees", 10)
+
+	require.Nil(t, err)
+	assert.Empty(t, results)
+
+	require.Nil(t, index.Delete(ctx, bitcoin.ID))
+
+	results, err = index.Search(ctx, "bitcoin", 10)
+
+	require.Nil(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, litecoin.ID, results[0].Crypto.ID)
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	upvoteSystem "github.com/RomuloSiebra/CryptoUpvoteSystem/proto/UpvoteSystem"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// voteSignatureWindow - Largest difference between the timestamp of a signed vote and the server
+	// clock. Nonces are remembered as long as their vote is accepted, so a signed vote counts once
+	voteSignatureWindow = 5 * time.Minute
+	// maxNonceLength - Longest nonce of a signed vote
+	maxNonceLength = 128
+	// voteMessageVersion - First line of signed vote messages, identifying their format
+	voteMessageVersion = "upvote-system-vote:v1"
+)
+
+// Prefixes of the voter IDs of signing keys, followed by the hex encoded public key
+const (
+	ed25519VoterPrefix   = "ed25519:"
+	secp256k1VoterPrefix = "secp256k1:"
+)
+
+// signedVoteRequest - Vote request or command carrying an optional wallet signature
+type signedVoteRequest interface {
+	GetSignature() *upvoteSystem.VoteSignature
+}
+
+// isKeyVoterID - Reports whether voterID names a signing key, only signed votes may use it
+func isKeyVoterID(voterID string) bool {
+	return strings.HasPrefix(voterID, ed25519VoterPrefix) || strings.HasPrefix(voterID, secp256k1VoterPrefix)
+}
+
+// voteDirectionName - Direction of a vote in signed messages
+func voteDirectionName(direction model.VoteDirection) string {
+	switch direction {
+	case model.Upvote:
+		return "up"
+	case model.Downvote:
+		return "down"
+	}
+	return "none"
+}
+
+// voteMessage - Message signed by the wallet of a vote on cryptoID in direction
+func voteMessage(cryptoID primitive.ObjectID, direction model.VoteDirection, nonce string, timestamp time.Time) []byte {
+	return []byte(strings.Join([]string{
+		voteMessageVersion,
+		cryptoID.Hex(),
+		voteDirectionName(direction),
+		nonce,
+		timestamp.UTC().Format(time.RFC3339Nano),
+	}, "\n"))
+}
+
+// validNonce - Reports whether nonce has 1 to maxNonceLength letters, digits, '-' or '_'
+func validNonce(nonce string) bool {
+	if nonce == "" || len(nonce) > maxNonceLength {
+		return false
+	}
+	for _, c := range nonce {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// signingVoterID - Voter ID of the public key of signature, without checking the signature itself
+func signingVoterID(signature *upvoteSystem.VoteSignature) (string, error) {
+	switch signature.GetKeyType() {
+	case upvoteSystem.KeyType_KEY_ED25519:
+		if len(signature.GetPublicKey()) != ed25519.PublicKeySize {
+			return "", status.Errorf(codes.InvalidArgument, "Invalid public key")
+		}
+		return ed25519VoterPrefix + hex.EncodeToString(signature.GetPublicKey()), nil
+	case upvoteSystem.KeyType_KEY_SECP256K1:
+		key, err := secp256k1.ParsePubKey(signature.GetPublicKey())
+		if err != nil {
+			return "", status.Errorf(codes.InvalidArgument, "Invalid public key")
+		}
+		// Both encodings of a key are the same voter
+		return secp256k1VoterPrefix + hex.EncodeToString(key.SerializeCompressed()), nil
+	}
+	return "", status.Errorf(codes.InvalidArgument, "Unknown key type")
+}
+
+// verifyVoteSignature - Checks signature signs a vote on cryptoID in direction within the window
+// around now and returns the voter ID of its key
+func verifyVoteSignature(signature *upvoteSystem.VoteSignature, cryptoID primitive.ObjectID, direction model.VoteDirection, now time.Time) (string, error) {
+	voterID, err := signingVoterID(signature)
+	if err != nil {
+		return "", err
+	}
+	if !validNonce(signature.GetNonce()) {
+		return "", status.Errorf(codes.InvalidArgument, "Invalid vote nonce")
+	}
+	if signature.GetTimestamp() == nil || signature.GetTimestamp().CheckValid() != nil {
+		return "", status.Errorf(codes.InvalidArgument, "Invalid vote timestamp")
+	}
+	timestamp := signature.GetTimestamp().AsTime()
+	if timestamp.Before(now.Add(-voteSignatureWindow)) || timestamp.After(now.Add(voteSignatureWindow)) {
+		return "", status.Errorf(codes.InvalidArgument, "Vote timestamp outside of the accepted window")
+	}
+
+	message := voteMessage(cryptoID, direction, signature.GetNonce(), timestamp)
+	var valid bool
+	switch signature.GetKeyType() {
+	case upvoteSystem.KeyType_KEY_ED25519:
+		valid = ed25519.Verify(signature.GetPublicKey(), message, signature.GetSignature())
+	case upvoteSystem.KeyType_KEY_SECP256K1:
+		hash := sha256.Sum256(message)
+		valid = verifySecp256k1(signature.GetPublicKey(), hash[:], signature.GetSignature())
+	}
+	if !valid {
+		return "", status.Errorf(codes.Unauthenticated, "Invalid vote signature")
+	}
+	return voterID, nil
+}
+
+// secp256k1SignatureSize - Size of a secp256k1 signature, r followed by s as 32 byte big endian integers
+const secp256k1SignatureSize = 64
+
+// verifySecp256k1 - Reports whether signature is a valid ECDSA signature of hash by publicKey. Both
+// low and high s are accepted, the nonce of the vote keeps a signature from counting twice
+func verifySecp256k1(publicKey []byte, hash []byte, signature []byte) bool {
+	key, err := secp256k1.ParsePubKey(publicKey)
+	if err != nil || len(signature) != secp256k1SignatureSize {
+		return false
+	}
+
+	// Values of n or more are rejected rather than reduced, Verify rejects zeros
+	var r, s secp256k1.ModNScalar
+	if r.SetByteSlice(signature[:32]) || s.SetByteSlice(signature[32:]) {
+		return false
+	}
+	return ecdsa.NewSignature(&r, &s).Verify(hash, key)
+}
+
+// voterIdentity - Voter of a vote on cryptoID in direction. Signed votes are cast by their key, once
+// per nonce, unsigned ones by the authenticated principal or else the requested voter or the voter-id
+// metadata. The nonce is used even when the vote itself fails afterwards, wallets sign again with a
+// new one
+func (s *server) voterIdentity(ctx context.Context, cryptoID primitive.ObjectID, direction model.VoteDirection, requested string, signature *upvoteSystem.VoteSignature) (string, error) {
+	if signature == nil {
+		voterID, err := voterIDFromContext(ctx, requested)
+		if err != nil {
+			return "", err
+		}
+		if s.requireSignedVotes || isKeyVoterID(voterID) {
+			return "", status.Errorf(codes.Unauthenticated, "Missing vote signature")
+		}
+		if voterID == "" {
+			return "", status.Errorf(codes.InvalidArgument, "Missing voter identity")
+		}
+		return voterID, nil
+	}
+
+	voterID, err := verifyVoteSignature(signature, cryptoID, direction, s.now())
+	if err != nil {
+		return "", err
+	}
+	if requested != "" && requested != voterID {
+		return "", status.Errorf(codes.InvalidArgument, "Voter ID doesn't match the signing key")
+	}
+
+	expires := signature.GetTimestamp().AsTime().Add(voteSignatureWindow)
+	if err := s.nonces.UseNonce(ctx, voterID, signature.GetNonce(), expires); err != nil {
+		return "", repositoryError(err)
+	}
+	return voterID, nil
+}
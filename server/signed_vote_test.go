@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	upvoteSystem "github.com/RomuloSiebra/CryptoUpvoteSystem/proto/UpvoteSystem"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ed25519Signer - Signs votes on cryptoID with a new Ed25519 key
+func ed25519Signer(t *testing.T) func(cryptoID string, direction model.VoteDirection, nonce string, timestamp time.Time) *upvoteSystem.VoteSignature {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.Nil(t, err)
+
+	return func(cryptoID string, direction model.VoteDirection, nonce string, timestamp time.Time) *upvoteSystem.VoteSignature {
+		id, err := primitive.ObjectIDFromHex(cryptoID)
+		require.Nil(t, err)
+		return &upvoteSystem.VoteSignature{
+			KeyType:   upvoteSystem.KeyType_KEY_ED25519,
+			PublicKey: publicKey,
+			Nonce:     nonce,
+			Timestamp: timestamppb.New(timestamp),
+			Signature: ed25519.Sign(privateKey, voteMessage(id, direction, nonce, timestamp)),
+		}
+	}
+}
+
+func TestVoteMessage(t *testing.T) {
+	id, err := primitive.ObjectIDFromHex("5f8f8c44b54764421b7156c3")
+	require.Nil(t, err)
+	timestamp := time.Date(2021, time.March, 1, 12, 0, 0, 500000000, time.FixedZone("BRT", -3*60*60))
+
+	assert.Equal(t, "upvote-system-vote:v1\n5f8f8c44b54764421b7156c3\ndown\nnonce-1\n2021-03-01T15:00:00.5Z",
+		string(voteMessage(id, model.Downvote, "nonce-1", timestamp)))
+}
+
+func TestSignedUpvote(t *testing.T) {
+	grpcServer := newServer(setupDB())
+	defer clearDB()
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Millisecond)
+	grpcServer.now = func() time.Time { return now }
+
+	cryptoResponse, err := grpcServer.CreateCrypto(ctx, &upvoteSystem.CreateCryptoRequest{
+		Crypto: &upvoteSystem.Cryptocurrency{Name: "Bitcoin", Description: "The most valuable cryptocurrency"},
+	})
+	require.Nil(t, err)
+	id := cryptoResponse.GetCrypto().GetId()
+	sign := ed25519Signer(t)
+
+	signature := sign(id, model.Upvote, "nonce-1", now)
+	response, err := grpcServer.UpvoteCrypto(ctx, &upvoteSystem.UpvoteCryptoRequest{Id: id, Signature: signature})
+	require.Nil(t, err)
+	assert.Equal(t, int32(1), response.GetCrypto().GetUpvote())
+
+	// Test the public key is the voter identity
+	voterID := "ed25519:" + hex.EncodeToString(signature.GetPublicKey())
+	_, err = grpcServer.RetractVote(ctx, &upvoteSystem.RetractVoteRequest{Id: id, VoterId: voterID})
+	require.NotNil(t, err)
+	assert.Equal(t, "rpc error: code = Unauthenticated desc = Missing vote signature", err.Error())
+
+	retracted, err := grpcServer.RetractVote(ctx, &upvoteSystem.RetractVoteRequest{Id: id, VoterId: voterID, Signature: sign(id, model.NoVote, "nonce-2", now)})
+	require.Nil(t, err)
+	assert.Equal(t, int32(0), retracted.GetCrypto().GetUpvote())
+
+	// Test replays are rejected before the counters change
+	_, err = grpcServer.UpvoteCrypto(ctx, &upvoteSystem.UpvoteCryptoRequest{Id: id, Signature: signature})
+	require.NotNil(t, err)
+	assert.Equal(t, "rpc error: code = AlreadyExists desc = Vote nonce already used", err.Error())
+
+	// Test signatures only cast the signed vote
+	_, err = grpcServer.DownvoteCrypto(ctx, &upvoteSystem.DownvoteCryptoRequest{Id: id, Signature: sign(id, model.Upvote, "nonce-3", now)})
+	require.NotNil(t, err)
+	assert.Equal(t, "rpc error: code = Unauthenticated desc = Invalid vote signature", err.Error())
+
+	other, err := grpcServer.CreateCrypto(ctx, &upvoteSystem.CreateCryptoRequest{
+		Crypto: &upvoteSystem.Cryptocurrency{Name: "Ethereum", Description: "Smart contracts platform"},
+	})
+	require.Nil(t, err)
+	_, err = grpcServer.UpvoteCrypto(ctx, &upvoteSystem.UpvoteCryptoRequest{Id: other.GetCrypto().GetId(), Signature: sign(id, model.Upvote, "nonce-4", now)})
+	require.NotNil(t, err)
+	assert.Equal(t, "rpc error: code = Unauthenticated desc = Invalid vote signature", err.Error())
+
+	// Test timestamps outside of the window
+	for _, timestamp := range []time.Time{now.Add(-voteSignatureWindow - time.Second), now.Add(voteSignatureWindow + time.Second)} {
+		_, err = grpcServer.UpvoteCrypto(ctx, &upvoteSystem.UpvoteCryptoRequest{Id: id, Signature: sign(id, model.Upvote, "nonce-5", timestamp)})
+		require.NotNil(t, err)
+		assert.Equal(t, "rpc error: code = InvalidArgument desc = Vote timestamp outside of the accepted window", err.Error())
+	}
+
+	// Test the requested voter must be the signing key
+	_, err = grpcServer.UpvoteCrypto(ctx, &upvoteSystem.UpvoteCryptoRequest{Id: id, VoterId: "mallory", Signature: sign(id, model.Upvote, "nonce-6", now)})
+	require.NotNil(t, err)
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = Voter ID doesn't match the signing key", err.Error())
+
+	// Test malformed signatures
+	malformed := map[string]*upvoteSystem.VoteSignature{
+		"Unknown key type":       {PublicKey: signature.GetPublicKey(), Nonce: "nonce-7", Timestamp: timestamppb.New(now)},
+		"Invalid public key":     {KeyType: upvoteSystem.KeyType_KEY_ED25519, PublicKey: []byte("short"), Nonce: "nonce-7", Timestamp: timestamppb.New(now)},
+		"Invalid vote nonce":     {KeyType: upvoteSystem.KeyType_KEY_ED25519, PublicKey: signature.GetPublicKey(), Nonce: "nonce\n7", Timestamp: timestamppb.New(now)},
+		"Invalid vote timestamp": {KeyType: upvoteSystem.KeyType_KEY_ED25519, PublicKey: signature.GetPublicKey(), Nonce: "nonce-7"},
+	}
+	for message, malformedSignature := range malformed {
+		_, err = grpcServer.UpvoteCrypto(ctx, &upvoteSystem.UpvoteCryptoRequest{Id: id, Signature: malformedSignature})
+		require.NotNil(t, err, message)
+		assert.Equal(t, "rpc error: code = InvalidArgument desc = "+message, err.Error())
+	}
+}
+
+// signSecp256k1 - Signature of hash by key as r || s, the compact signature without its recovery code
+func signSecp256k1(key *secp256k1.PrivateKey, hash []byte) []byte {
+	return ecdsa.SignCompact(key, hash, true)[1:]
+}
+
+func TestVerifySecp256k1(t *testing.T) {
+	decodeHex := func(s string) []byte {
+		data, err := hex.DecodeString(s)
+		require.Nil(t, err)
+		return data
+	}
+
+	// Test a signature made by openssl, with both encodings of its key
+	compressed := decodeHex("022708fbc4124b6060b186e0c84de519d477270e3c9be5edf3d88e516fb78f59da")
+	uncompressed := decodeHex("042708fbc4124b6060b186e0c84de519d477270e3c9be5edf3d88e516fb78f59dacf6d87fbf91a3403513a8672c4b6679cfd7e3d0382c5d76d28933b40f4a8180e")
+	hash := sha256.Sum256([]byte("upvote-system test vector"))
+	signature := decodeHex("2f0fa73de9ffa4ecee05e1933fee19954a6472a0fa51e12330387a4caea20d19" +
+		"3614e9ca0ab589f4e452097a5349e4514141640b3801ee2608d9b4d987def2f9")
+
+	assert.True(t, verifySecp256k1(compressed, hash[:], signature))
+	assert.True(t, verifySecp256k1(uncompressed, hash[:], signature))
+
+	other := sha256.Sum256([]byte("another message"))
+	assert.False(t, verifySecp256k1(compressed, other[:], signature))
+
+	// Test the opposite s is accepted, votes are deduplicated by nonce
+	var s secp256k1.ModNScalar
+	s.SetByteSlice(signature[32:])
+	negated := s.Negate().Bytes()
+	highS := append(append([]byte{}, signature[:32]...), negated[:]...)
+	assert.True(t, verifySecp256k1(compressed, hash[:], highS))
+
+	// Test malformed signatures and keys fail without panicking
+	tampered := append([]byte{}, signature...)
+	tampered[40] ^= 1
+	order := decodeHex("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141")
+	offCurve := append([]byte{}, uncompressed...)
+	offCurve[64] ^= 1
+	for name, test := range map[string]struct{ key, signature []byte }{
+		"tampered":      {compressed, tampered},
+		"truncated":     {compressed, signature[:63]},
+		"zeros":         {compressed, make([]byte, secp256k1SignatureSize)},
+		"r of n":        {compressed, append(append([]byte{}, order...), signature[32:]...)},
+		"s of n":        {compressed, append(append([]byte{}, signature[:32]...), order...)},
+		"off curve key": {offCurve, signature},
+		"x not below p": {decodeHex("02fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc30"), signature},
+		"wrong prefix":  {append([]byte{5}, compressed[1:]...), signature},
+		"empty key":     {nil, signature},
+	} {
+		assert.False(t, verifySecp256k1(test.key, hash[:], test.signature), name)
+	}
+}
+
+func TestSignedDownvoteSecp256k1(t *testing.T) {
+	grpcServer := newServer(setupDB())
+	defer clearDB()
+	ctx := context.Background()
+
+	cryptoResponse, err := grpcServer.CreateCrypto(ctx, &upvoteSystem.CreateCryptoRequest{
+		Crypto: &upvoteSystem.Cryptocurrency{Name: "Bitcoin", Description: "The most valuable cryptocurrency"},
+	})
+	require.Nil(t, err)
+	id := cryptoResponse.GetCrypto().GetId()
+	cryptoID, _ := primitive.ObjectIDFromHex(id)
+
+	key, err := secp256k1.GeneratePrivateKey()
+	require.Nil(t, err)
+	sign := func(publicKey []byte, direction model.VoteDirection, nonce string) *upvoteSystem.VoteSignature {
+		timestamp := time.Now()
+		hash := sha256.Sum256(voteMessage(cryptoID, direction, nonce, timestamp))
+		return &upvoteSystem.VoteSignature{
+			KeyType:   upvoteSystem.KeyType_KEY_SECP256K1,
+			PublicKey: publicKey,
+			Nonce:     nonce,
+			Timestamp: timestamppb.New(timestamp),
+			Signature: signSecp256k1(key, hash[:]),
+		}
+	}
+
+	compressed := key.PubKey().SerializeCompressed()
+	response, err := grpcServer.DownvoteCrypto(ctx, &upvoteSystem.DownvoteCryptoRequest{Id: id, Signature: sign(compressed, model.Downvote, "nonce-1")})
+	require.Nil(t, err)
+	assert.Equal(t, int32(1), response.GetCrypto().GetDownvote())
+
+	// Test the uncompressed key is the same voter
+	uncompressed := key.PubKey().SerializeUncompressed()
+
+	_, err = grpcServer.DownvoteCrypto(ctx, &upvoteSystem.DownvoteCryptoRequest{Id: id, Signature: sign(uncompressed, model.Downvote, "nonce-2")})
+	require.NotNil(t, err)
+	assert.Equal(t, "rpc error: code = AlreadyExists desc = Voter already voted on this Cryptocurrency", err.Error())
+
+	// Test the nonce is tracked per key, whatever its encoding
+	_, err = grpcServer.UpvoteCrypto(ctx, &upvoteSystem.UpvoteCryptoRequest{Id: id, Signature: sign(uncompressed, model.Upvote, "nonce-1")})
+	require.NotNil(t, err)
+	assert.Equal(t, "rpc error: code = AlreadyExists desc = Vote nonce already used", err.Error())
+
+	changed, err := grpcServer.ChangeVote(ctx, &upvoteSystem.ChangeVoteRequest{
+		Id:        id,
+		VoterId:   "secp256k1:" + hex.EncodeToString(compressed),
+		Direction: upvoteSystem.VoteDirection_VOTE_UP,
+		Signature: sign(compressed, model.Upvote, "nonce-3"),
+	})
+	require.Nil(t, err)
+	assert.Equal(t, int32(1), changed.GetCrypto().GetUpvote())
+	assert.Equal(t, int32(0), changed.GetCrypto().GetDownvote())
+}
+
+func TestRequireSignedVotes(t *testing.T) {
+	grpcServer := newServer(setupDB())
+	defer clearDB()
+	grpcServer.requireSignedVotes = true
+	ctx := context.Background()
+
+	cryptoResponse, err := grpcServer.CreateCrypto(ctx, &upvoteSystem.CreateCryptoRequest{
+		Crypto: &upvoteSystem.Cryptocurrency{Name: "Bitcoin", Description: "The most valuable cryptocurrency"},
+	})
+	require.Nil(t, err)
+	id := cryptoResponse.GetCrypto().GetId()
+
+	_, err = grpcServer.UpvoteCrypto(ctx, &upvoteSystem.UpvoteCryptoRequest{Id: id, VoterId: "voter"})
+	require.NotNil(t, err)
+	assert.Equal(t, "rpc error: code = Unauthenticated desc = Missing vote signature", err.Error())
+
+	// Test stream commands are held to the same rule
+	command := &upvoteSystem.VoteCommand{Id: id, Direction: upvoteSystem.VoteDirection_VOTE_UP}
+	_, err = grpcServer.applyVoteCommand(ctx, command)
+	require.NotNil(t, err)
+	assert.Equal(t, "rpc error: code = Unauthenticated desc = Missing vote signature", err.Error())
+
+	command.Signature = ed25519Signer(t)(id, model.Upvote, "nonce-1", time.Now())
+	crypto, err := grpcServer.applyVoteCommand(ctx, command)
+	require.Nil(t, err)
+	assert.Equal(t, int32(1), crypto.Upvote)
+}
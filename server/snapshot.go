@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+)
+
+// defaultSnapshotInterval - Used when SNAPSHOT_INTERVAL is not set
+const defaultSnapshotInterval = time.Hour
+
+// snapshotScheduler - Records the counters of every cryptocurrency once per interval.
+// Ticks are aligned to multiples of interval and stamped with the tick time, so
+// replicas sharing a storage record the same snapshot only once
+type snapshotScheduler struct {
+	crypto    CryptoRepository
+	snapshots SnapshotRepository
+	interval  time.Duration
+	now       func() time.Time
+}
+
+func newSnapshotScheduler(crypto CryptoRepository, snapshots SnapshotRepository, interval time.Duration) *snapshotScheduler {
+	return &snapshotScheduler{
+		crypto:    crypto,
+		snapshots: snapshots,
+		interval:  interval,
+		now:       time.Now,
+	}
+}
+
+// Run takes snapshots until ctx is done
+func (s *snapshotScheduler) Run(ctx context.Context) {
+	if err := s.catchUp(ctx); err != nil {
+		log.Printf("Snapshot catch-up failed: %v", err)
+	}
+
+	for {
+		next := s.now().Truncate(s.interval).Add(s.interval)
+		timer := time.NewTimer(next.Sub(s.now()))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if err := s.takeSnapshot(ctx, next); err != nil {
+			log.Printf("Snapshot at %v failed: %v", next, err)
+		}
+	}
+}
+
+// catchUp records the current tick when the server was down at it. Counters of
+// older missed ticks are unknown, so those ticks are skipped instead of guessed
+func (s *snapshotScheduler) catchUp(ctx context.Context) error {
+	last, err := s.snapshots.LastSnapshotTime(ctx)
+	if err != nil {
+		return err
+	}
+
+	current := s.now().Truncate(s.interval)
+	if !last.Before(current) {
+		return nil
+	}
+
+	if !last.IsZero() {
+		if missed := int(current.Sub(last)/s.interval) - 1; missed > 0 {
+			log.Printf("Skipping %d missed snapshots since %v", missed, last)
+		}
+	}
+	return s.takeSnapshot(ctx, current)
+}
+
+func (s *snapshotScheduler) takeSnapshot(ctx context.Context, timestamp time.Time) error {
+	allCrypto, err := s.crypto.List(ctx, ListQuery{})
+	if err != nil {
+		return err
+	}
+
+	snapshots := make([]model.Snapshot, 0, len(allCrypto))
+	for _, crypto := range allCrypto {
+		snapshots = append(snapshots, model.Snapshot{
+			CryptoID:  crypto.ID,
+			Upvote:    crypto.Upvote,
+			Downvote:  crypto.Downvote,
+			Sum:       crypto.Upvote - crypto.Downvote,
+			Timestamp: timestamp.UTC(),
+		})
+	}
+	return s.snapshots.SaveSnapshots(ctx, snapshots)
+}
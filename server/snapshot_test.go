@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSnapshotCatchUp(t *testing.T) {
+	storage := setupDB()
+	defer clearDB()
+	ctx := context.Background()
+
+	crypto, err := storage.Create(ctx, model.Crypto{ID: primitive.NewObjectID(), Name: "Bitcoin", Description: "The most valuable cryptocurrency"})
+	require.Nil(t, err)
+
+	_, err = storage.UpdateVote(ctx, crypto.ID, "voter", func(model.VoteDirection) (model.VoteDirection, error) {
+		return model.Downvote, nil
+	})
+	require.Nil(t, err)
+
+	now := time.Date(2021, 2, 25, 10, 30, 0, 0, time.UTC)
+	scheduler := newSnapshotScheduler(storage, storage, time.Hour)
+	scheduler.now = func() time.Time { return now }
+
+	// Test first start records the current tick
+	require.Nil(t, scheduler.catchUp(ctx))
+
+	last, err := storage.LastSnapshotTime(ctx)
+
+	require.Nil(t, err)
+	assert.Equal(t, time.Date(2021, 2, 25, 10, 0, 0, 0, time.UTC), last.UTC())
+
+	// Test restart within the same tick skips the snapshot
+	_, err = storage.UpdateVote(ctx, crypto.ID, "voter", func(model.VoteDirection) (model.VoteDirection, error) {
+		return model.Upvote, nil
+	})
+	require.Nil(t, err)
+
+	require.Nil(t, scheduler.catchUp(ctx))
+
+	last, err = storage.LastSnapshotTime(ctx)
+
+	require.Nil(t, err)
+	assert.Equal(t, time.Date(2021, 2, 25, 10, 0, 0, 0, time.UTC), last.UTC())
+
+	// Test restart after missed ticks records only the current one
+	now = time.Date(2021, 2, 25, 13, 5, 0, 0, time.UTC)
+
+	require.Nil(t, scheduler.catchUp(ctx))
+
+	last, err = storage.LastSnapshotTime(ctx)
+
+	require.Nil(t, err)
+	assert.Equal(t, time.Date(2021, 2, 25, 13, 0, 0, 0, time.UTC), last.UTC())
+
+	snapshots, err := storage.ListSnapshots(ctx, crypto.ID, time.Unix(0, 0), now)
+
+	require.Nil(t, err)
+	require.Len(t, snapshots, 2)
+
+	assert.Equal(t, int32(-1), snapshots[0].Sum)
+	assert.Equal(t, int32(1), snapshots[1].Sum)
+
+	// Test saving a tick twice keeps the first snapshot
+	_, err = storage.UpdateVote(ctx, crypto.ID, "voter", func(model.VoteDirection) (model.VoteDirection, error) {
+		return model.NoVote, nil
+	})
+	require.Nil(t, err)
+
+	require.Nil(t, scheduler.takeSnapshot(ctx, time.Date(2021, 2, 25, 13, 0, 0, 0, time.UTC)))
+
+	snapshots, err = storage.ListSnapshots(ctx, crypto.ID, time.Unix(0, 0), now)
+
+	require.Nil(t, err)
+	require.Len(t, snapshots, 2)
+
+	assert.Equal(t, int32(1), snapshots[1].Sum)
+}
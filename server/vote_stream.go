@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	upvoteSystem "github.com/RomuloSiebra/CryptoUpvoteSystem/proto/UpvoteSystem"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// VoteStream applies the vote commands in the order they are received and acknowledges each one,
+// a failed command is reported in its acknowledgement and the stream goes on
+func (s *server) VoteStream(stream upvoteSystem.UpvoteSystem_VoteStreamServer) error {
+	for {
+		command, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		ack := &upvoteSystem.VoteAck{RequestId: command.GetRequestId()}
+
+		crypto, err := s.applyVoteCommand(stream.Context(), command)
+		if err != nil {
+			st := status.Convert(err)
+			ack.Code = uint32(st.Code())
+			ack.Message = st.Message()
+		} else {
+			ack.Crypto = toCryptocurrency(crypto)
+		}
+
+		if err := stream.Send(ack); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *server) applyVoteCommand(ctx context.Context, command *upvoteSystem.VoteCommand) (model.Crypto, error) {
+	cryptoID, err := primitive.ObjectIDFromHex(command.GetId())
+	if err != nil {
+		return model.Crypto{}, status.Errorf(codes.InvalidArgument, fmt.Sprintf("%v", err))
+	}
+
+	switch command.GetDirection() {
+	case upvoteSystem.VoteDirection_VOTE_UP, upvoteSystem.VoteDirection_VOTE_DOWN, upvoteSystem.VoteDirection_VOTE_NONE:
+	default:
+		return model.Crypto{}, status.Errorf(codes.InvalidArgument, "Invalid vote direction")
+	}
+
+	direction := directionFromProto(command.GetDirection())
+	voterID, err := s.voterIdentity(ctx, cryptoID, direction, command.GetVoterId(), command.GetSignature())
+	if err != nil {
+		return model.Crypto{}, err
+	}
+
+	if direction == model.NoVote {
+		return s.retractVote(ctx, cryptoID, voterID)
+	}
+	return s.castVote(ctx, cryptoID, voterID, direction)
+}
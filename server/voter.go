@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	model "github.com/RomuloSiebra/CryptoUpvoteSystem/model"
+	upvoteSystem "github.com/RomuloSiebra/CryptoUpvoteSystem/proto/UpvoteSystem"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// voterIDMetadataKey - Request metadata identifying the voter when the request has no voter_id
+const voterIDMetadataKey = "voter-id"
+
+// errDuplicateVote - Rejects a vote in the same direction as the voter current vote
+var errDuplicateVote = errors.New("voter already voted on this cryptocurrency")
+
+// errVoteNotFound - Rejects retracting or changing a vote the voter never cast
+var errVoteNotFound = errors.New("voter has no vote on this cryptocurrency")
+
+// directionFromProto - Converts the request direction, VOTE_NONE and unknown values map to model.NoVote
+func directionFromProto(direction upvoteSystem.VoteDirection) model.VoteDirection {
+	switch direction {
+	case upvoteSystem.VoteDirection_VOTE_UP:
+		return model.Upvote
+	case upvoteSystem.VoteDirection_VOTE_DOWN:
+		return model.Downvote
+	}
+	return model.NoVote
+}
+
+// voterIDFromContext - Returns requested or, when empty, the voter-id metadata of the call. Callers
+// authenticated by a Principal always vote as the principal, another requested voter is rejected
+func voterIDFromContext(ctx context.Context, requested string) (string, error) {
+	if requested == "" {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(voterIDMetadataKey); len(values) > 0 {
+				requested = values[0]
+			}
+		}
+	}
+
+	principal, ok := principalFromContext(ctx)
+	if !ok {
+		return requested, nil
+	}
+	if requested != "" && requested != principal.ID {
+		return "", status.Errorf(codes.PermissionDenied, "Voter ID doesn't match the authenticated caller")
+	}
+	return principal.ID, nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+
+	upvoteSystem "github.com/RomuloSiebra/CryptoUpvoteSystem/proto/UpvoteSystem"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// eventTypes - Proto type of each hub event type
+var eventTypes = map[eventType]upvoteSystem.CryptoEventType{
+	eventCreated: upvoteSystem.CryptoEventType_EVENT_CREATED,
+	eventUpdated: upvoteSystem.CryptoEventType_EVENT_UPDATED,
+	eventDeleted: upvoteSystem.CryptoEventType_EVENT_DELETED,
+	eventVoted:   upvoteSystem.CryptoEventType_EVENT_VOTED,
+}
+
+func (s *server) WatchCryptos(request *upvoteSystem.WatchCryptosRequest, stream upvoteSystem.UpvoteSystem_WatchCryptosServer) error {
+	var cryptoIDs []primitive.ObjectID
+	for _, id := range request.GetIds() {
+		cryptoID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, fmt.Sprintf("%v", err))
+		}
+		cryptoIDs = append(cryptoIDs, cryptoID)
+	}
+
+	sub, _ := s.hub.subscribe(cryptoIDs...)
+	defer s.hub.unsubscribe(sub)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-sub.dropped:
+			return status.Errorf(codes.ResourceExhausted, "Subscriber too slow")
+		case e := <-sub.updates:
+			response := &upvoteSystem.WatchCryptosResponse{
+				Type:      eventTypes[e.kind],
+				Crypto:    toCryptocurrency(e.crypto),
+				Sequence:  e.sequence,
+				Timestamp: timestamppb.New(e.timestamp),
+			}
+			if err := stream.Send(response); err != nil {
+				return nil
+			}
+		}
+	}
+}
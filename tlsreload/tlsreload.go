@@ -0,0 +1,220 @@
+// Package tlsreload serves TLS certificates and CA bundles read from files, reloading them when the
+// files change so certificates rotate without restarts
+package tlsreload
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultInterval - Time between checks of the files when no interval is configured
+const DefaultInterval = 30 * time.Second
+
+// Reloader - Key pair and optional CA bundle loaded from files. A change of any file loads all of
+// them again, a failed load keeps serving the previous ones so a half copied rotation does no harm
+type Reloader struct {
+	certFile string
+	keyFile  string
+	caFile   string
+
+	mutex       sync.RWMutex
+	certificate *tls.Certificate
+	pool        *x509.CertPool
+	versions    map[string]fileVersion
+}
+
+// fileVersion - Modification time and size of a loaded file
+type fileVersion struct {
+	modTime time.Time
+	size    int64
+}
+
+// New - Loads the key pair of certFile and keyFile and, unless empty, the PEM bundle of caFile.
+// Either the key pair or the CA bundle may be left empty
+func New(certFile string, keyFile string, caFile string) (*Reloader, error) {
+	if (certFile == "") != (keyFile == "") {
+		return nil, errors.New("certificate and key files must be set together")
+	}
+	if certFile == "" && caFile == "" {
+		return nil, errors.New("no certificate or CA file set")
+	}
+
+	r := &Reloader{certFile: certFile, keyFile: keyFile, caFile: caFile}
+	if _, err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Reloader) files() []string {
+	var files []string
+	for _, file := range []string{r.certFile, r.keyFile, r.caFile} {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// Reload - Loads the files again when any of them changed since the last load and reports whether it did
+func (r *Reloader) Reload() (bool, error) {
+	versions := make(map[string]fileVersion)
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return false, err
+		}
+		versions[file] = fileVersion{modTime: info.ModTime(), size: info.Size()}
+	}
+
+	r.mutex.RLock()
+	changed := !sameVersions(r.versions, versions)
+	r.mutex.RUnlock()
+	if !changed {
+		return false, nil
+	}
+
+	var certificate *tls.Certificate
+	if r.certFile != "" {
+		loaded, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+		if err != nil {
+			return false, err
+		}
+		certificate = &loaded
+	}
+
+	var pool *x509.CertPool
+	if r.caFile != "" {
+		data, err := ioutil.ReadFile(r.caFile)
+		if err != nil {
+			return false, err
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return false, fmt.Errorf("no certificates found in %s", r.caFile)
+		}
+	}
+
+	r.mutex.Lock()
+	r.certificate, r.pool, r.versions = certificate, pool, versions
+	r.mutex.Unlock()
+	return true, nil
+}
+
+func sameVersions(a map[string]fileVersion, b map[string]fileVersion) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for file, version := range a {
+		if other, found := b[file]; !found || !other.modTime.Equal(version.modTime) || other.size != version.size {
+			return false
+		}
+	}
+	return true
+}
+
+// Run - Checks the files for changes every interval until ctx is done, logging failed reloads
+func (r *Reloader) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := r.Reload()
+			if err != nil {
+				log.Printf("Error reloading certificates: %v", err)
+			} else if reloaded {
+				log.Printf("Reloaded certificates from %v", r.files())
+			}
+		}
+	}
+}
+
+// Certificate - Current key pair, nil without certificate file
+func (r *Reloader) Certificate() *tls.Certificate {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.certificate
+}
+
+// Pool - Current CA bundle, nil without CA file
+func (r *Reloader) Pool() *x509.CertPool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.pool
+}
+
+// ServerConfig - Server config presenting the current certificate and negotiating nextProtos.
+// With a CA file, clients must present a certificate signed by the current bundle
+func (r *Reloader) ServerConfig(nextProtos ...string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: nextProtos,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return r.Certificate(), nil
+		},
+		// The config of each handshake replaces this one, so it repeats every setting
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			config := &tls.Config{MinVersion: tls.VersionTLS12, NextProtos: nextProtos}
+			if certificate := r.Certificate(); certificate != nil {
+				config.Certificates = []tls.Certificate{*certificate}
+			}
+			if pool := r.Pool(); pool != nil {
+				config.ClientCAs = pool
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return config, nil
+		},
+	}
+}
+
+// ClientConfig - Client config verifying serverName against the current CA bundle, or the system
+// roots without CA file, and presenting the current certificate when one is set
+func (r *Reloader) ClientConfig(serverName string) *tls.Config {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			if certificate := r.Certificate(); certificate != nil {
+				return certificate, nil
+			}
+			return &tls.Certificate{}, nil
+		},
+	}
+
+	if r.caFile != "" {
+		// RootCAs is read once per config, verify against the current bundle instead. The default
+		// verification is skipped only to be replaced by the same checks with the reloaded roots
+		config.InsecureSkipVerify = true
+		config.VerifyConnection = func(state tls.ConnectionState) error {
+			if len(state.PeerCertificates) == 0 {
+				return errors.New("no server certificate")
+			}
+			if state.ServerName == "" {
+				return errors.New("no server name to verify")
+			}
+			options := x509.VerifyOptions{
+				DNSName:       state.ServerName,
+				Roots:         r.Pool(),
+				Intermediates: x509.NewCertPool(),
+			}
+			for _, certificate := range state.PeerCertificates[1:] {
+				options.Intermediates.AddCert(certificate)
+			}
+			_, err := state.PeerCertificates[0].Verify(options)
+			return err
+		}
+	}
+	return config
+}
@@ -0,0 +1,209 @@
+package tlsreload
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// authority - Test CA issuing leaf certificates
+type authority struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	pem         []byte
+}
+
+func newAuthority(t *testing.T, name string) authority {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.Nil(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.Nil(t, err)
+
+	return authority{certificate: certificate, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue - PEM certificate and key of commonName, valid for localhost
+func (a authority) issue(t *testing.T, commonName string) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, a.certificate, &key.PublicKey, a.key)
+	require.Nil(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.Nil(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// writeFile - Writes data to name in dir with a modification time distinct from the previous write
+func writeFile(t *testing.T, dir string, name string, data []byte, modTime time.Time) string {
+	path := filepath.Join(dir, name)
+	require.Nil(t, ioutil.WriteFile(path, data, 0600))
+	require.Nil(t, os.Chtimes(path, modTime, modTime))
+	return path
+}
+
+// handshake - Connects client to server over loopback, returning the client common name seen by
+// the server and the handshake error of the client
+func handshake(t *testing.T, server *tls.Config, client *tls.Config) (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer listener.Close()
+
+	commonName := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			commonName <- ""
+			return
+		}
+		defer conn.Close()
+
+		serverConn := tls.Server(conn, server)
+		if err := serverConn.Handshake(); err != nil || len(serverConn.ConnectionState().VerifiedChains) == 0 {
+			commonName <- ""
+			return
+		}
+		commonName <- serverConn.ConnectionState().VerifiedChains[0][0].Subject.CommonName
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.Nil(t, err)
+	defer conn.Close()
+
+	clientConn := tls.Client(conn, client)
+	clientConn.SetDeadline(time.Now().Add(5 * time.Second))
+	err = clientConn.Handshake()
+	if err == nil {
+		// TLS 1.3 clients finish first, a rejected certificate shows on the first read
+		_, err = clientConn.Read(make([]byte, 1))
+		if err == io.EOF {
+			err = nil
+		}
+	}
+	return <-commonName, err
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tlsreload")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	start := time.Now().Add(-time.Minute)
+	ca := newAuthority(t, "Test CA")
+	serverCert, serverKey := ca.issue(t, "server")
+	clientCert, clientKey := ca.issue(t, "gateway")
+
+	server, err := New(
+		writeFile(t, dir, "server.pem", serverCert, start),
+		writeFile(t, dir, "server.key", serverKey, start),
+		writeFile(t, dir, "client-ca.pem", ca.pem, start),
+	)
+	require.Nil(t, err)
+	client, err := New(
+		writeFile(t, dir, "client.pem", clientCert, start),
+		writeFile(t, dir, "client.key", clientKey, start),
+		writeFile(t, dir, "ca.pem", ca.pem, start),
+	)
+	require.Nil(t, err)
+
+	commonName, err := handshake(t, server.ServerConfig(), client.ClientConfig("localhost"))
+	require.Nil(t, err)
+	assert.Equal(t, "gateway", commonName)
+
+	// Test the server name is verified
+	_, err = handshake(t, server.ServerConfig(), client.ClientConfig("example.com"))
+	assert.NotNil(t, err)
+
+	// Test clients without certificate are rejected
+	anonymous, err := New("", "", filepath.Join(dir, "ca.pem"))
+	require.Nil(t, err)
+	_, err = handshake(t, server.ServerConfig(), anonymous.ClientConfig("localhost"))
+	assert.NotNil(t, err)
+
+	// Test unchanged files aren't loaded again
+	reloaded, err := server.Reload()
+	require.Nil(t, err)
+	assert.False(t, reloaded)
+
+	// Test rotating to another CA without restarting
+	rotated := newAuthority(t, "Rotated CA")
+	serverCert, serverKey = rotated.issue(t, "server")
+	writeFile(t, dir, "server.pem", serverCert, start.Add(time.Second))
+	writeFile(t, dir, "server.key", serverKey, start.Add(time.Second))
+
+	_, err = handshake(t, server.ServerConfig(), client.ClientConfig("localhost"))
+	require.Nil(t, err)
+
+	reloaded, err = server.Reload()
+	require.Nil(t, err)
+	assert.True(t, reloaded)
+
+	_, err = handshake(t, server.ServerConfig(), client.ClientConfig("localhost"))
+	assert.NotNil(t, err)
+
+	writeFile(t, dir, "ca.pem", rotated.pem, start.Add(time.Second))
+	reloaded, err = client.Reload()
+	require.Nil(t, err)
+	assert.True(t, reloaded)
+
+	// The server still trusts the client certificate of the previous CA
+	commonName, err = handshake(t, server.ServerConfig(), client.ClientConfig("localhost"))
+	require.Nil(t, err)
+	assert.Equal(t, "gateway", commonName)
+
+	// Test a broken rotation keeps the loaded certificate
+	writeFile(t, dir, "server.key", []byte("not a key"), start.Add(2*time.Second))
+	_, err = server.Reload()
+	assert.NotNil(t, err)
+
+	_, err = handshake(t, server.ServerConfig(), client.ClientConfig("localhost"))
+	assert.Nil(t, err)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New("cert.pem", "", "")
+	assert.NotNil(t, err)
+
+	_, err = New("", "", "")
+	assert.NotNil(t, err)
+
+	_, err = New("", "", "missing.pem")
+	assert.NotNil(t, err)
+}